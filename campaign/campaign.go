@@ -0,0 +1,173 @@
+package campaign
+
+//
+// The "campaign" package loads hand-crafted levels from files and keeps how far players have progressed.
+//
+
+import (
+	"encoding/json"
+	"github.com/kjirou/gRPC-sample-net-game/models"
+	"github.com/kjirou/gRPC-sample-net-game/utils"
+	"github.com/pkg/errors"
+	"io/ioutil"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// The file name of the progress store in the home directory.
+const DefaultProgressFileName = ".tower-of-go-campaigns.json"
+
+type levelJSON struct {
+	// If it is empty, the file name is used.
+	Name string `json:"name"`
+	// Seconds. If it is 0, the default time limit is used.
+	TimeLimit float64 `json:"timeLimit"`
+	// Lines of glyphs in the format of `models.ParseFieldText`. Either it or the `field` is required.
+	Rows []string `json:"rows"`
+	// A field in the format of `models.Field.MarshalJSON`.
+	Field *models.Field `json:"field"`
+}
+
+// Load a level from a file.
+// A "*.txt" file is a field in the format of `models.ParseFieldText`.
+// A "*.json" file is a level with optional name and time limit, e.g. {"timeLimit": 20, "rows": ["#@.<#"]}.
+func LoadLevel(path string) (*models.Level, error) {
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	extension := filepath.Ext(path)
+	name := strings.TrimSuffix(filepath.Base(path), extension)
+
+	switch extension {
+	case ".txt":
+		field, parseErr := models.ParseFieldText(string(content))
+		if parseErr != nil {
+			return nil, errors.Wrapf(parseErr, "The %q level is invalid.", path)
+		}
+		return &models.Level{Name: name, Field: field}, nil
+	case ".json":
+		data := &levelJSON{}
+		unmarshalErr := json.Unmarshal(content, data)
+		if unmarshalErr != nil {
+			return nil, errors.Wrapf(unmarshalErr, "The %q level is invalid.", path)
+		}
+		field := data.Field
+		if len(data.Rows) > 0 {
+			var parseErr error
+			field, parseErr = models.ParseFieldText(strings.Join(data.Rows, "\n"))
+			if parseErr != nil {
+				return nil, errors.Wrapf(parseErr, "The %q level is invalid.", path)
+			}
+		}
+		if field == nil {
+			return nil, errors.Errorf("The %q level has neither rows nor a field.", path)
+		} else if data.TimeLimit < 0 {
+			return nil, errors.Errorf("The time limit of the %q level is negative.", path)
+		}
+		if data.Name != "" {
+			name = data.Name
+		}
+		return &models.Level{
+			Name: name,
+			Field: field,
+			TimeLimit: time.Duration(data.TimeLimit * float64(time.Second)),
+		}, nil
+	}
+	return nil, errors.Errorf("The %q file is not a level.", path)
+}
+
+// Load all level files in the directory in alphabetical order of file names, e.g. "01-walk.txt", "02-turn.json".
+// Files of other extensions are ignored.
+func LoadLevels(directory string) ([]*models.Level, error) {
+	fileInfos, err := ioutil.ReadDir(directory)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	fileNames := make([]string, 0)
+	for _, fileInfo := range fileInfos {
+		extension := filepath.Ext(fileInfo.Name())
+		if !fileInfo.IsDir() && (extension == ".txt" || extension == ".json") {
+			fileNames = append(fileNames, fileInfo.Name())
+		}
+	}
+	sort.Strings(fileNames)
+	if len(fileNames) == 0 {
+		return nil, errors.Errorf("The %q directory has no levels.", directory)
+	}
+	levels := make([]*models.Level, 0, len(fileNames))
+	for _, fileName := range fileNames {
+		level, loadErr := LoadLevel(filepath.Join(directory, fileName))
+		if loadErr != nil {
+			return nil, loadErr
+		}
+		levels = append(levels, level)
+	}
+	return levels, nil
+}
+
+type ProgressStore interface {
+	// Return the number of levels that have been cleared in order from the first one.
+	// The `campaignID` identifies the campaign, e.g. the absolute path of its directory.
+	GetClearedLevelCount(campaignID string) (int, error)
+	SaveClearedLevelCount(campaignID string, count int) error
+}
+
+// A store that saves progress of all campaigns to a JSON file.
+type FileProgressStore struct {
+	mutex sync.Mutex
+	path string
+}
+
+func (store *FileProgressStore) GetPath() string {
+	return store.path
+}
+
+// The `mutex` must be locked by the caller.
+func (store *FileProgressStore) load() (map[string]int, error) {
+	counts := make(map[string]int)
+	err := utils.ReadJSONFile(store.path, &counts)
+	if err != nil {
+		return nil, err
+	}
+	return counts, nil
+}
+
+func (store *FileProgressStore) GetClearedLevelCount(campaignID string) (int, error) {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+	counts, err := store.load()
+	if err != nil {
+		return 0, err
+	}
+	return counts[campaignID], nil
+}
+
+func (store *FileProgressStore) SaveClearedLevelCount(campaignID string, count int) error {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+	counts, err := store.load()
+	if err != nil {
+		return err
+	}
+	counts[campaignID] = count
+	return utils.WriteJSONFile(store.path, counts)
+}
+
+func CreateFileProgressStore(path string) *FileProgressStore {
+	return &FileProgressStore{
+		path: path,
+	}
+}
+
+// Create a store of the `DefaultProgressFileName` in the home directory.
+func CreateDefaultFileProgressStore() (*FileProgressStore, error) {
+	path, err := utils.GetHomeFilePath(DefaultProgressFileName)
+	if err != nil {
+		return nil, err
+	}
+	return CreateFileProgressStore(path), nil
+}
@@ -0,0 +1,164 @@
+package campaign
+
+import (
+	"github.com/kjirou/gRPC-sample-net-game/solver"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func createTestingDirectory(t *testing.T) string {
+	directory, err := ioutil.TempDir("", "campaign")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		os.RemoveAll(directory)
+	})
+	return directory
+}
+
+func writeTestingFile(t *testing.T, directory string, name string, content string) string {
+	path := filepath.Join(directory, name)
+	err := ioutil.WriteFile(path, []byte(content), 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestLoadLevel_NotTD(t *testing.T) {
+	t.Run("テキストのレベルはファイル名を名前にし、既定の制限時間になる", func(t *testing.T) {
+		directory := createTestingDirectory(t)
+		path := writeTestingFile(t, directory, "01-walk.txt", "#####\n#@.<#\n#####\n")
+		level, err := LoadLevel(path)
+		if err != nil {
+			t.Fatal(err)
+		} else if level.Name != "01-walk" {
+			t.Fatal("名前が違う")
+		} else if level.TimeLimit != 0 {
+			t.Fatal("制限時間が違う")
+		} else if level.Field.GetUpstairsPosition().GetX() != 3 {
+			t.Fatal("フィールドが違う")
+		}
+	})
+
+	t.Run("JSON のレベルは名前と制限時間を指定できる", func(t *testing.T) {
+		directory := createTestingDirectory(t)
+		path := writeTestingFile(t, directory, "02.json", `{"name": "Turn", "timeLimit": 12.5, "rows": ["#####", "#@.<#", "#####"]}`)
+		level, err := LoadLevel(path)
+		if err != nil {
+			t.Fatal(err)
+		} else if level.Name != "Turn" {
+			t.Fatal("名前が違う")
+		} else if level.TimeLimit != 12500*time.Millisecond {
+			t.Fatal("制限時間が違う")
+		}
+	})
+
+	t.Run("JSON のレベルはフィールドの JSON でも書ける", func(t *testing.T) {
+		directory := createTestingDirectory(t)
+		path := writeTestingFile(t, directory, "03.json", `{"field": {`+
+			`"objects": [["wall", "empty", "empty", "wall"]],`+
+			`"floorObjects": [["empty", "empty", "upstairs", "empty"]],`+
+			`"entrance": {"y": 0, "x": 1}}}`)
+		level, err := LoadLevel(path)
+		if err != nil {
+			t.Fatal(err)
+		} else if level.Field.ToText() != "#@<#\n" {
+			t.Fatal("フィールドが違う")
+		}
+	})
+
+	testCases := []struct {
+		title string
+		name string
+		content string
+	}{
+		{title: "壊れたフィールド", name: "a.txt", content: "#@#"},
+		{title: "行もフィールドもない JSON", name: "a.json", content: `{"name": "a"}`},
+		{title: "負の制限時間", name: "a.json", content: `{"timeLimit": -1, "rows": ["#@<#"]}`},
+		{title: "対応していない拡張子", name: "a.md", content: "#@<#"},
+	}
+	for _, testCase := range testCases {
+		testCase := testCase
+		t.Run(testCase.title+"はエラーを返す", func(t *testing.T) {
+			directory := createTestingDirectory(t)
+			_, err := LoadLevel(writeTestingFile(t, directory, testCase.name, testCase.content))
+			if err == nil {
+				t.Fatal("エラーを返さない")
+			}
+		})
+	}
+}
+
+func TestLoadLevels_NotTD(t *testing.T) {
+	t.Run("ファイル名の順に読み込み、他の拡張子は無視する", func(t *testing.T) {
+		directory := createTestingDirectory(t)
+		writeTestingFile(t, directory, "02-b.json", `{"rows": ["#@.<#"]}`)
+		writeTestingFile(t, directory, "01-a.txt", "#@<#")
+		writeTestingFile(t, directory, "README.md", "Levels")
+		levels, err := LoadLevels(directory)
+		if err != nil {
+			t.Fatal(err)
+		} else if len(levels) != 2 {
+			t.Fatal("レベルの数が違う")
+		} else if levels[0].Name != "01-a" || levels[1].Name != "02-b" {
+			t.Fatal("順序が違う")
+		}
+	})
+
+	t.Run("レベルがないときはエラーを返す", func(t *testing.T) {
+		_, err := LoadLevels(createTestingDirectory(t))
+		if err == nil {
+			t.Fatal("エラーを返さない")
+		} else if !strings.Contains(err.Error(), "no levels") {
+			t.Fatal("意図したエラーメッセージではない")
+		}
+	})
+
+	t.Run("同梱のチュートリアルは全て解ける", func(t *testing.T) {
+		levels, err := LoadLevels(filepath.Join("..", "campaigns", "tutorial"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, level := range levels {
+			if _, ok := solver.SolveFloor(level.Field); !ok {
+				t.Fatalf("%s を解けない", level.Name)
+			}
+		}
+	})
+}
+
+func TestFileProgressStore_NotTD(t *testing.T) {
+	t.Run("キャンペーンごとにクリアしたレベル数を保存する", func(t *testing.T) {
+		path := filepath.Join(createTestingDirectory(t), DefaultProgressFileName)
+		store := CreateFileProgressStore(path)
+		count, err := store.GetClearedLevelCount("a")
+		if err != nil {
+			t.Fatal(err)
+		} else if count != 0 {
+			t.Fatal("初期値が0ではない")
+		}
+		store.SaveClearedLevelCount("a", 2)
+		store.SaveClearedLevelCount("b", 1)
+		anotherStore := CreateFileProgressStore(path)
+		if count, _ := anotherStore.GetClearedLevelCount("a"); count != 2 {
+			t.Fatal("a の進捗が違う")
+		} else if count, _ := anotherStore.GetClearedLevelCount("b"); count != 1 {
+			t.Fatal("b の進捗が違う")
+		}
+	})
+
+	t.Run("壊れたファイルはエラーを返す", func(t *testing.T) {
+		directory := createTestingDirectory(t)
+		store := CreateFileProgressStore(writeTestingFile(t, directory, DefaultProgressFileName, "{"))
+		_, err := store.GetClearedLevelCount("a")
+		if err == nil {
+			t.Fatal("エラーを返さない")
+		}
+	})
+}
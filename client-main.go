@@ -1,12 +1,25 @@
+//go:build client
+// +build client
+
 package main
 
 import (
+	"context"
 	"flag"
 	"fmt"
+	"github.com/kjirou/gRPC-sample-net-game/campaign"
 	"github.com/kjirou/gRPC-sample-net-game/controller"
+	"github.com/kjirou/gRPC-sample-net-game/leaderboard"
+	"github.com/kjirou/gRPC-sample-net-game/models"
+	pb "github.com/kjirou/gRPC-sample-net-game/proto"
+	"github.com/kjirou/gRPC-sample-net-game/utils"
 	"github.com/kjirou/gRPC-sample-net-game/views"
 	"github.com/nsf/termbox-go"
+	"google.golang.org/grpc"
 	"math/rand"
+	"os"
+	"path/filepath"
+	"strings"
 	"time"
 )
 
@@ -58,43 +71,315 @@ func runMainLoop(controller *controller.Controller) {
 	}
 }
 
+func runRemoteMainLoop(remoteController *controller.RemoteController) {
+	ticker := time.NewTicker(time.Microsecond*16666)
+	defer ticker.Stop()
+	for range ticker.C {
+		handleMainLoopErr := remoteController.HandleMainLoop()
+		if handleMainLoopErr != nil {
+			termbox.Close()
+			errMessage, _ := fmt.Printf("%+v", handleMainLoopErr)
+			panic(errMessage)
+		}
+	}
+}
+
+func runRemotePlayResponseReceiver(remoteController *controller.RemoteController) {
+	receivePlayResponsesErr := remoteController.ReceivePlayResponses()
+	if receivePlayResponsesErr != nil {
+		termbox.Close()
+		errMessage, _ := fmt.Printf("%+v", receivePlayResponsesErr)
+		panic(errMessage)
+	}
+}
+
+func runRemoteScreenReceiver(remoteController *controller.RemoteController) {
+	receiveScreensErr := remoteController.ReceiveScreens(context.Background(), func() {
+		drawTerminal(remoteController.GetScreen())
+	})
+	if receiveScreensErr != nil {
+		termbox.Close()
+		errMessage, _ := fmt.Printf("%+v", receiveScreensErr)
+		panic(errMessage)
+	}
+}
+
+type keyPressHandler interface {
+	HandleKeyPress(ch rune, key termbox.Key)
+}
+
+func observeTermboxEvents(handler keyPressHandler) {
+	didQuitApplication := false
+	for !didQuitApplication {
+		event := termbox.PollEvent()
+		switch event.Type {
+		case termbox.EventKey:
+			// Quit the application. Only this operation is resolved with priority.
+			if event.Key == termbox.KeyEsc || event.Key == termbox.KeyCtrlC || event.Key == termbox.KeyCtrlQ {
+				didQuitApplication = true
+				break
+			}
+			handler.HandleKeyPress(event.Ch, event.Key)
+		}
+	}
+}
+
+func initTermbox() {
+	termboxErr := termbox.Init()
+	if termboxErr != nil {
+		panic(termboxErr)
+	}
+	termbox.SetInputMode(termbox.InputEsc)
+	termbox.Clear(termbox.ColorDefault, termbox.ColorDefault)
+}
+
+func printRooms(gameClient pb.GameServiceClient) {
+	response, err := gameClient.ListRooms(context.Background(), &pb.ListRoomsRequest{})
+	if err != nil {
+		panic(err)
+	}
+	for _, room := range response.GetRooms() {
+		fmt.Printf("%s\t%s\t%s\t%d\t%v\n", room.GetRoomId(), room.GetName(), room.GetMode(), room.GetSeed(), room.GetPlayerIds())
+	}
+}
+
+func runSpectatorSnapshotReceiver(spectatorController *controller.SpectatorController) {
+	receiveSnapshotsErr := spectatorController.ReceiveSnapshots(func() {
+		drawTerminal(spectatorController.GetScreen())
+	})
+	if receiveSnapshotsErr != nil {
+		termbox.Close()
+		errMessage, _ := fmt.Printf("%+v", receiveSnapshotsErr)
+		panic(errMessage)
+	}
+}
+
+// Watch the room without a hero.
+func spectate(gameClient pb.GameServiceClient, roomID string) {
+	spectatorController, createSpectatorControllerErr := controller.CreateSpectatorController(
+		context.Background(), gameClient, roomID)
+	if createSpectatorControllerErr != nil {
+		panic(createSpectatorControllerErr)
+	}
+	initTermbox()
+	defer termbox.Close()
+	go runSpectatorSnapshotReceiver(spectatorController)
+	observeTermboxEvents(spectatorController)
+}
+
+// Play as a thin client of the game server.
+// If the `roomID` is empty, it creates a new room with the `createRoomRequest`.
+func mainWithServer(
+	serverAddress string, roomID string, playerID string, createRoomRequest *pb.CreateRoomRequest,
+	debugMode bool, listsRooms bool, spectates bool) {
+	connection, dialErr := grpc.Dial(serverAddress, grpc.WithInsecure())
+	if dialErr != nil {
+		panic(dialErr)
+	}
+	defer connection.Close()
+	gameClient := pb.NewGameServiceClient(connection)
+
+	if listsRooms {
+		printRooms(gameClient)
+		return
+	} else if spectates {
+		spectate(gameClient, roomID)
+		return
+	}
+
+	if roomID == "" {
+		response, createRoomErr := gameClient.CreateRoom(context.Background(), createRoomRequest)
+		if createRoomErr != nil {
+			panic(createRoomErr)
+		}
+		roomID = response.GetRoom().GetRoomId()
+	}
+
+	remoteController, createRemoteControllerErr := controller.CreateRemoteController(
+		context.Background(), gameClient, roomID, playerID)
+	if createRemoteControllerErr != nil {
+		panic(createRemoteControllerErr)
+	}
+	defer remoteController.Leave(context.Background())
+
+	if debugMode {
+		fmt.Println(convertScreenToText(remoteController.GetScreen()))
+	} else {
+		initTermbox()
+		defer termbox.Close()
+		drawTerminal(remoteController.GetScreen())
+		go runRemoteMainLoop(remoteController)
+		go runRemoteScreenReceiver(remoteController)
+		go runRemotePlayResponseReceiver(remoteController)
+		observeTermboxEvents(remoteController)
+	}
+}
+
 func main() {
 	var debugMode bool
+	var serverAddress string
+	var playerID string
+	var roomID string
+	var listsRooms bool
+	var modeName string
+	var spectates bool
+	var seed int64
+	var mazeGeneratorNamesText string
+	var loopDensity float64
+	var stairsPlacementName string
+	var targetsDifficulty bool
+	var monsterCount int
+	var itemCount int
+	var hasDoors bool
+	var campaignDirectory string
 	flag.BoolVar(&debugMode, "debug", false, "Runs with debug mode.")
+	flag.StringVar(&roomID, "room", "", "The room ID to join in the game server. If it is omitted, a new room is created.")
+	flag.BoolVar(&listsRooms, "list-rooms", false, "Prints rooms in the game server.")
+	flag.BoolVar(&spectates, "spectate", false, "Watches the room of the -room option without a hero.")
+	flag.StringVar(&modeName, "mode", "standard", "The game mode of a new room in the game server, \"standard\", \"race\" or \"coop\".")
+	flag.Int64Var(&seed, "seed", 0, "The seed of mazes to reproduce the same games. If it is 0, each game has a random seed.")
+	flag.StringVar(&mazeGeneratorNamesText, "maze", "", fmt.Sprintf(
+		"Comma-separated maze generators that floors use in turn, from %s.",
+		strings.Join(utils.GetMazeGeneratorNames(), ", ")))
+	flag.Float64Var(&loopDensity, "loops", 0, "The fraction of walls between rooms that are broken to make loops, from 0 to 1.")
+	flag.StringVar(&stairsPlacementName, "stairs", "fixed", fmt.Sprintf(
+		"How to place the entrance and the upstairs on each floor, from %s.",
+		strings.Join(models.GetStairsPlacementNames(), ", ")))
+	flag.BoolVar(&targetsDifficulty, "ramp", false, "Makes floors harder as the floor number rises.")
+	flag.IntVar(&monsterCount, "monsters", 0, "The number of monsters on each floor. Touching them costs time.")
+	flag.IntVar(&itemCount, "items", 0,
+		"The number of items on each floor, e.g. hourglasses that add time, compasses and teleporters.")
+	flag.BoolVar(&hasDoors, "doors", false, "Places coloured doors on later floors, which open only with the keys of the same colours.")
+	flag.StringVar(&campaignDirectory, "campaign", "",
+		"Plays level files in the directory in order instead of generated mazes, e.g. \"campaigns/tutorial\".")
+	flag.StringVar(&playerID, "player", "",
+		"The player ID in the game server, which is required with the -server option. "+
+			"Without the server, it is the name on scores, and $USER is used if it is omitted.")
+	flag.StringVar(&serverAddress, "server", "", "Connects to the game server of the address, e.g. \"localhost:50051\".")
 	flag.Parse()
 
+	mazeGeneratorNames := make([]string, 0)
+	if mazeGeneratorNamesText != "" {
+		mazeGeneratorNames = strings.Split(mazeGeneratorNamesText, ",")
+	}
+	for _, mazeGeneratorName := range mazeGeneratorNames {
+		_, findMazeGeneratorErr := utils.FindMazeGenerator(mazeGeneratorName)
+		if findMazeGeneratorErr != nil {
+			fmt.Println(findMazeGeneratorErr.Error())
+			return
+		}
+	}
+
+	if loopDensity < 0 || loopDensity > 1 {
+		fmt.Println("The -loops option must be between 0 and 1.")
+		return
+	}
+
+	if monsterCount < 0 {
+		fmt.Println("The -monsters option must not be negative.")
+		return
+	}
+
+	if itemCount < 0 {
+		fmt.Println("The -items option must not be negative.")
+		return
+	}
+
+	stairsPlacement, findStairsPlacementErr := models.FindStairsPlacement(stairsPlacementName)
+	if findStairsPlacementErr != nil {
+		fmt.Println(findStairsPlacementErr.Error())
+		return
+	}
+
+	gameOptions := &models.GameOptions{
+		MazeGeneratorNames: mazeGeneratorNames,
+		LoopDensity: loopDensity,
+		StairsPlacement: stairsPlacement,
+		TargetsDifficulty: targetsDifficulty,
+		MonsterCount: monsterCount,
+		ItemCount: itemCount,
+		HasDoors: hasDoors,
+	}
+
+	if serverAddress != "" {
+		if campaignDirectory != "" {
+			fmt.Println("The -campaign option can not be used with the -server option.")
+			return
+		} else if spectates && roomID == "" {
+			fmt.Println("The -room option is required with the -spectate option.")
+			return
+		} else if playerID == "" && !listsRooms && !spectates {
+			fmt.Println("The -player option is required with the -server option.")
+			return
+		}
+		mode, modeOk := pb.GameMode_value["GAME_MODE_" + strings.ToUpper(modeName)]
+		if !modeOk {
+			fmt.Printf("The %q game mode is invalid.\n", modeName)
+			return
+		}
+		createRoomRequest := &pb.CreateRoomRequest{
+			Mode: pb.GameMode(mode),
+			Seed: seed,
+			MazeGeneratorNames: gameOptions.MazeGeneratorNames,
+			LoopDensity: gameOptions.LoopDensity,
+			StairsPlacement: gameOptions.StairsPlacement.GetName(),
+			TargetsDifficulty: gameOptions.TargetsDifficulty,
+			MonsterCount: int32(gameOptions.MonsterCount),
+			ItemCount: int32(gameOptions.ItemCount),
+			HasDoors: gameOptions.HasDoors,
+		}
+		mainWithServer(serverAddress, roomID, playerID, createRoomRequest, debugMode, listsRooms, spectates)
+		return
+	}
+
 	rand.Seed(time.Now().UnixNano())
 
-	controller, createControllerErr := controller.CreateController()
+	// Without the server, scores are kept only on this machine.
+	scoreStore, createScoreStoreErr := leaderboard.CreateDefaultFileStore()
+	if createScoreStoreErr != nil {
+		panic(createScoreStoreErr)
+	}
+
+	playerName := playerID
+	if playerName == "" {
+		playerName = os.Getenv("USER")
+	}
+	if playerName == "" {
+		playerName = controller.LocalPlayerID
+	}
+
+	controller, createControllerErr := controller.CreateController(scoreStore, playerName, seed, gameOptions)
 	if createControllerErr != nil {
 		panic(createControllerErr)
 	}
 
+	if campaignDirectory != "" {
+		levels, loadLevelsErr := campaign.LoadLevels(campaignDirectory)
+		if loadLevelsErr != nil {
+			fmt.Println(loadLevelsErr.Error())
+			return
+		}
+		campaignID, absErr := filepath.Abs(campaignDirectory)
+		if absErr != nil {
+			panic(absErr)
+		}
+		progressStore, createProgressStoreErr := campaign.CreateDefaultFileProgressStore()
+		if createProgressStoreErr != nil {
+			panic(createProgressStoreErr)
+		}
+		startCampaignErr := controller.StartCampaign(campaignID, levels, progressStore)
+		if startCampaignErr != nil {
+			panic(startCampaignErr)
+		}
+	}
+
 	if debugMode {
 		fmt.Println(convertScreenToText(controller.GetScreen()))
 	} else {
-		termboxErr := termbox.Init()
-		if termboxErr != nil {
-			panic(termboxErr)
-		}
-		termbox.SetInputMode(termbox.InputEsc)
-		termbox.Clear(termbox.ColorDefault, termbox.ColorDefault)
+		initTermbox()
 		defer termbox.Close()
 		drawTerminal(controller.GetScreen())
 		go runMainLoop(controller)
-		// Observe termbox events.
-		didQuitApplication := false
-		for !didQuitApplication {
-			event := termbox.PollEvent()
-			switch event.Type {
-			case termbox.EventKey:
-				// Quit the application. Only this operation is resolved with priority.
-				if event.Key == termbox.KeyEsc || event.Key == termbox.KeyCtrlC || event.Key == termbox.KeyCtrlQ {
-					didQuitApplication = true
-					break
-				}
-				controller.HandleKeyPress(event.Ch, event.Key)
-			}
-		}
+		observeTermboxEvents(controller)
 	}
 }
@@ -18,54 +18,126 @@ package controller
 //
 
 import (
+	"fmt"
+	"github.com/kjirou/gRPC-sample-net-game/campaign"
+	"github.com/kjirou/gRPC-sample-net-game/leaderboard"
 	"github.com/kjirou/gRPC-sample-net-game/models"
 	"github.com/kjirou/gRPC-sample-net-game/utils"
 	"github.com/kjirou/gRPC-sample-net-game/reducers"
+	"github.com/kjirou/gRPC-sample-net-game/solver"
 	"github.com/kjirou/gRPC-sample-net-game/views"
 	"github.com/nsf/termbox-go"
 	"github.com/pkg/errors"
 	"math"
+	"math/rand"
 	"time"
 )
 
-func mapFieldElementToScreenCellProps(fieldElement *models.FieldElement) *views.ScreenCellProps {
-	symbol := '.'
-	fg := termbox.ColorWhite
-	bg := termbox.ColorBlack
-	if !fieldElement.IsObjectEmpty() {
-		switch fieldElement.GetObjectClass() {
-		case "hero":
-			symbol = '@'
-			fg = termbox.ColorMagenta
-		case "wall":
-			symbol = '#'
-			fg = termbox.ColorYellow
-		default:
-			symbol = '?'
+// The player ID of the only hero in the local play.
+const LocalPlayerID = "local"
+
+// The number of scores in the high-score screen.
+const HighScoresLimit = 15
+
+func mapGameModeToName(mode models.GameMode) string {
+	switch mode {
+	case models.GameModeRace:
+		return "race"
+	case models.GameModeCoop:
+		return "coop"
+	}
+	return "standard"
+}
+
+func MapScoresToHighScoresProps(scores []*leaderboard.Score) *views.HighScoresProps {
+	highScores := make([]*views.HighScoreProps, len(scores))
+	for index, score := range scores {
+		highScores[index] = &views.HighScoreProps{
+			PlayerName: score.PlayerName,
+			Floors: score.Floors,
+			Date: score.AchievedAt.Local().Format("2006-01-02"),
+			Seed: score.Seed,
+			Mode: mapGameModeToName(score.Mode),
 		}
-	} else {
-		switch fieldElement.GetFloorObjectClass() {
-		case "upstairs":
-			symbol = '<'
+	}
+	return &views.HighScoresProps{
+		HighScores: highScores,
+	}
+}
+
+var objectColorsToAttributes = map[models.ObjectColor]termbox.Attribute{
+	models.ObjectColorWhite: termbox.ColorWhite,
+	models.ObjectColorBlue: termbox.ColorBlue,
+	models.ObjectColorCyan: termbox.ColorCyan,
+	models.ObjectColorGreen: termbox.ColorGreen,
+	models.ObjectColorMagenta: termbox.ColorMagenta,
+	models.ObjectColorRed: termbox.ColorRed,
+	models.ObjectColorYellow: termbox.ColorYellow,
+}
+
+// The `playerID` is the player who sees the field. The player's own hero is distinguished from others.
+// The object is shown over the floor object, with the glyph and the color of its kind.
+func mapFieldElementToScreenCellProps(fieldElement *models.FieldElement, playerID string) *views.ScreenCellProps {
+	kind := fieldElement.GetObjectKind()
+	if fieldElement.IsObjectEmpty() {
+		kind = fieldElement.GetFloorObjectKind()
+	}
+	definition := kind.GetDefinition()
+	fg := objectColorsToAttributes[definition.Color]
+	if hero, ok := fieldElement.GetHero(); ok {
+		if hero.HasReachedUpstairs() {
+			// In the co-op mode, it shows who have touched the upstairs.
 			fg = termbox.ColorGreen
+		} else if hero.GetPlayerID() != playerID {
+			fg = termbox.ColorBlue
 		}
 	}
 	return &views.ScreenCellProps{
-		Symbol: symbol,
+		Symbol: definition.Glyph,
 		Foreground: fg,
-		Background: bg,
+		Background: termbox.ColorBlack,
 	}
 }
 
-func mapStateModelToScreenProps(state *models.State) (*views.ScreenProps, error) {
+// Map the whole field to cells without centering on any heroes. It is for spectators.
+func MapFieldToScreenCellProps(field *models.Field, playerID string) [][]*views.ScreenCellProps {
+	rowLength := field.MeasureRowLength()
+	columnLength := field.MeasureColumnLength()
+	cells := make([][]*views.ScreenCellProps, rowLength)
+	for y := 0; y < rowLength; y++ {
+		cellsRow := make([]*views.ScreenCellProps, columnLength)
+		for x := 0; x < columnLength; x++ {
+			fieldElement, _ := field.At(&utils.MatrixPosition{Y: y, X: x})
+			cellsRow[x] = mapFieldElementToScreenCellProps(fieldElement, playerID)
+		}
+		cells[y] = cellsRow
+	}
+	return cells
+}
+
+// Map the state to the screen of the player. The field is centered on the player's hero.
+func MapStateModelToScreenProps(state *models.State, playerID string) (*views.ScreenProps, error) {
 	game := state.GetGame()
 	field := state.GetField()
 
-	heroElement, heroElementErr := field.GetElementOfHero()
+	heroElement, heroElementErr := field.GetElementOfHero(playerID)
 	if heroElementErr != nil {
 		return nil, errors.WithStack(heroElementErr)
 	}
 	heroPosition := heroElement.GetPosition()
+	hero, _ := heroElement.GetHero()
+
+	// A compass shows the route to the upstairs for a while.
+	routePositions := make(map[utils.MatrixPosition]bool)
+	if hero.ShowsRoute(state.GetExecutionTime()) {
+		// The route may take a detour to keys of doors.
+		route, ok := solver.FindPathWithKeys(field, heroPosition, field.GetUpstairsPosition(), hero.GetKeyKinds())
+		if ok {
+			for _, position := range route {
+				routePositions[*position] = true
+			}
+		}
+	}
 
 	// Cells of the field.
 	fieldCellsRowLength := 13
@@ -78,12 +150,16 @@ func mapStateModelToScreenProps(state *models.State) (*views.ScreenProps, error)
 	for y := 0; y < fieldCellsRowLength; y++ {
 		cellsRow := make([]*views.ScreenCellProps, fieldCellsColumnLength)
 		for x := 0; x < fieldCellsColumnLength; x++ {
-			fieldElement, fieldElementOk := field.At(&utils.MatrixPosition{
+			position := utils.MatrixPosition{
 				Y: y - fieldCellsCenterPosition.GetY() + heroPosition.GetY(),
 				X: x - fieldCellsCenterPosition.GetX() + heroPosition.GetX(),
-			})
+			}
+			fieldElement, fieldElementOk := field.At(&position)
 			if fieldElementOk {
-				cellsRow[x] = mapFieldElementToScreenCellProps(fieldElement)
+				cellsRow[x] = mapFieldElementToScreenCellProps(fieldElement, playerID)
+				if routePositions[position] {
+					cellsRow[x].Background = termbox.ColorBlue
+				}
 			} else {
 				cellsRow[x] = &views.ScreenCellProps{
 					Symbol: ' ',
@@ -95,11 +171,45 @@ func mapStateModelToScreenProps(state *models.State) (*views.ScreenProps, error)
 		fieldCells[y] = cellsRow
 	}
 
+	// In the race mode, each player has own floor and is ranked by them.
+	floorNumber := game.GetFloorNumber()
+	rankingLines := make([]string, 0)
+	if game.GetMode() == models.GameModeRace {
+		floorNumber = hero.GetClearedFloorCount() + 1
+		if game.IsFinished() {
+			for index, rankedHero := range state.RankHeroes() {
+				rankingLines = append(rankingLines, fmt.Sprintf(
+					"%d. %-12.12s %2dF", index+1, rankedHero.GetPlayerID(), rankedHero.GetClearedFloorCount()+1))
+			}
+		}
+	}
+
+	inventoryItemNames := make([]string, 0)
+	for _, itemKind := range hero.GetInventory().GetItemKinds() {
+		inventoryItemNames = append(inventoryItemNames, itemKind.GetName())
+	}
+	keyNames := make([]string, 0)
+	for _, keyKind := range hero.GetKeyKinds() {
+		keyNames = append(keyNames, keyKind.GetName())
+	}
+
+	// The name of the campaign's level.
+	sideLines := rankingLines
+	if level, ok := game.GetCurrentLevel(); ok {
+		sideLines = append(sideLines, fmt.Sprintf("Level: %s", level.Name))
+	}
+
 	// Lank message.
 	lankMessage := ""
 	lankMessageForeground := termbox.ColorWhite
-	if game.IsFinished() {
-		score := game.GetFloorNumber()
+	if game.IsFinished() && len(game.GetLevels()) > 0 {
+		lankMessage = "Try again..."
+		if game.HasClearedAllLevels() {
+			lankMessage = "Complete!"
+			lankMessageForeground = termbox.ColorCyan
+		}
+	} else if game.IsFinished() {
+		score := floorNumber
 		switch {
 			case score == 3:
 				lankMessage = "Good!"
@@ -121,9 +231,14 @@ func mapStateModelToScreenProps(state *models.State) (*views.ScreenProps, error)
 	return &views.ScreenProps{
 		FieldCells: fieldCells,
 		RemainingTime: game.CalculateRemainingTime(state.GetExecutionTime()).Seconds(),
-		FloorNumber: game.GetFloorNumber(),
+		FloorNumber: floorNumber,
 		LankMessage: lankMessage,
 		LankMessageForeground: lankMessageForeground,
+		SideLines: sideLines,
+		Seed: game.GetSeed(),
+		DigCharges: hero.GetRemainingDigCharges(),
+		InventoryItemNames: inventoryItemNames,
+		KeyNames: keyNames,
 	}, nil
 }
 
@@ -133,6 +248,21 @@ type Controller struct {
 	lastMainLoopRanAt time.Time
 	state  *models.State
 	screen *views.Screen
+	// Scores are submitted to it when games have finished.
+	scoreStore leaderboard.Store
+	// The name on scores. The hero is always `LocalPlayerID` in the local play.
+	playerName string
+	// The seed of every game. If it is 0, each game has a random seed.
+	seed int64
+	// States share the game with their previous ones, so the previous finish is remembered here.
+	wasGameFinished bool
+	showsHighScores bool
+	// It is loaded when the high-score screen is opened.
+	highScoresProps *views.HighScoresProps
+	// They are set only in the campaign mode.
+	campaignID string
+	progressStore campaign.ProgressStore
+	savedClearedLevelCount int
 }
 
 func (controller *Controller) GetScreen() *views.Screen {
@@ -166,9 +296,90 @@ func (controller *Controller) CalculateIntervalToNextMainLoop(now time.Time) tim
 	return nextInterval
 }
 
+// Open or close the high-score screen.
+func (controller *Controller) toggleHighScores() {
+	controller.showsHighScores = !controller.showsHighScores
+	if !controller.showsHighScores {
+		return
+	}
+	scores, err := controller.scoreStore.GetTopScores(HighScoresLimit)
+	if err != nil {
+		// A broken store should not stop the game.
+		controller.highScoresProps = &views.HighScoresProps{Message: "Failed to load scores."}
+		return
+	}
+	controller.highScoresProps = MapScoresToHighScoresProps(scores)
+}
+
+// Return the floor number to resume the campaign from. A completed campaign is played from the first level again.
+func calculateResumingFloorNumber(clearedLevelCount int, levelCount int) int {
+	if clearedLevelCount >= levelCount {
+		return 1
+	}
+	return clearedLevelCount + 1
+}
+
+// Play the levels instead of generated mazes. It resumes the campaign from the progress in the `progressStore`.
+// The `campaignID` identifies the campaign in the store.
+func (controller *Controller) StartCampaign(
+	campaignID string, levels []*models.Level, progressStore campaign.ProgressStore) error {
+	clearedLevelCount, err := progressStore.GetClearedLevelCount(campaignID)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	game := controller.state.GetGame()
+	game.SetLevels(levels)
+	game.SetFirstFloorNumber(calculateResumingFloorNumber(clearedLevelCount, len(levels)))
+	controller.campaignID = campaignID
+	controller.progressStore = progressStore
+	controller.savedClearedLevelCount = clearedLevelCount
+	return nil
+}
+
+// Save cleared levels, and let the next game restart from the level where the player is.
+func (controller *Controller) saveCampaignProgress(game *models.Game) error {
+	if !game.IsStarted() {
+		return nil
+	}
+	clearedLevelCount := game.GetFloorNumber() - 1
+	game.SetFirstFloorNumber(calculateResumingFloorNumber(clearedLevelCount, len(game.GetLevels())))
+	if clearedLevelCount <= controller.savedClearedLevelCount {
+		return nil
+	}
+	err := controller.progressStore.SaveClearedLevelCount(controller.campaignID, clearedLevelCount)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	controller.savedClearedLevelCount = clearedLevelCount
+	return nil
+}
+
 func (controller *Controller) Dispatch(newState *models.State) error {
 	controller.state = newState
-	screenProps, err := mapStateModelToScreenProps(controller.state)
+	isGameFinished := newState.GetGame().IsFinished()
+	if controller.progressStore != nil {
+		// Levels of the campaign are not ranked with random mazes.
+		saveErr := controller.saveCampaignProgress(newState.GetGame())
+		if saveErr != nil {
+			return saveErr
+		}
+	} else if !controller.wasGameFinished && isGameFinished {
+		score, createScoreErr := leaderboard.CreateScoreOfHero(newState, LocalPlayerID, time.Now())
+		if createScoreErr != nil {
+			return errors.WithStack(createScoreErr)
+		}
+		score.PlayerName = controller.playerName
+		submitScoreErr := controller.scoreStore.SubmitScore(score)
+		if submitScoreErr != nil {
+			return errors.WithStack(submitScoreErr)
+		}
+	}
+	controller.wasGameFinished = isGameFinished
+	if controller.showsHighScores {
+		controller.screen.RenderHighScores(controller.highScoresProps)
+		return nil
+	}
+	screenProps, err := MapStateModelToScreenProps(controller.state, LocalPlayerID)
 	controller.screen.Render(screenProps)
 	return err
 }
@@ -182,18 +393,38 @@ func (controller *Controller) HandleMainLoop(elapsedTime time.Duration) (*models
 	var err error
 
 	switch {
+	// Open or close the high-score screen. The game goes on behind it.
+	case ch == 'r':
+		controller.toggleHighScores()
+		newState, err = reducers.AdvanceOnlyTime(*controller.state, elapsedTime)
 	// Start or restart a game.
 	case ch == 's':
-		newState, err = reducers.StartOrRestartGame(*controller.state, elapsedTime)
+		seed := controller.seed
+		if seed == 0 {
+			seed = rand.Int63()
+		}
+		newState, err = reducers.StartOrRestartGame(*controller.state, elapsedTime, seed)
 	// Move the hero.
 	case key == termbox.KeyArrowUp || ch == 'k':
-		newState, err = reducers.WalkHero(*controller.state, elapsedTime, reducers.FourDirectionUp)
+		newState, err = reducers.WalkHero(*controller.state, elapsedTime, LocalPlayerID, reducers.FourDirectionUp)
 	case key == termbox.KeyArrowRight || ch == 'l':
-		newState, err = reducers.WalkHero(*controller.state, elapsedTime, reducers.FourDirectionRight)
+		newState, err = reducers.WalkHero(*controller.state, elapsedTime, LocalPlayerID, reducers.FourDirectionRight)
 	case key == termbox.KeyArrowDown || ch == 'j':
-		newState, err = reducers.WalkHero(*controller.state, elapsedTime, reducers.FourDirectionDown)
+		newState, err = reducers.WalkHero(*controller.state, elapsedTime, LocalPlayerID, reducers.FourDirectionDown)
 	case key == termbox.KeyArrowLeft || ch == 'h':
-		newState, err = reducers.WalkHero(*controller.state, elapsedTime, reducers.FourDirectionLeft)
+		newState, err = reducers.WalkHero(*controller.state, elapsedTime, LocalPlayerID, reducers.FourDirectionLeft)
+	// Dig a wall next to the hero.
+	case ch == 'K':
+		newState, err = reducers.DigWall(*controller.state, elapsedTime, LocalPlayerID, reducers.FourDirectionUp)
+	case ch == 'L':
+		newState, err = reducers.DigWall(*controller.state, elapsedTime, LocalPlayerID, reducers.FourDirectionRight)
+	case ch == 'J':
+		newState, err = reducers.DigWall(*controller.state, elapsedTime, LocalPlayerID, reducers.FourDirectionDown)
+	case ch == 'H':
+		newState, err = reducers.DigWall(*controller.state, elapsedTime, LocalPlayerID, reducers.FourDirectionLeft)
+	// Use an item in the inventory by its number.
+	case ch >= '1' && ch < '1'+models.InventoryCapacity:
+		newState, err = reducers.UseItem(*controller.state, elapsedTime, LocalPlayerID, int(ch-'1'))
 	default:
 		newState, err = reducers.AdvanceOnlyTime(*controller.state, elapsedTime)
 	}
@@ -205,14 +436,31 @@ func (controller *Controller) HandleKeyPress(ch rune, key termbox.Key) {
 	controller.setKeyInputs(ch, key)
 }
 
-func CreateController() (*Controller, error) {
-	controller := &Controller{}
+// The `scoreStore` is the local-only leaderboard.
+// If the `seed` is 0, each game has a random seed.
+// Scores are recorded with the `playerName`.
+// The `options` decide how floors are generated.
+func CreateController(
+	scoreStore leaderboard.Store, playerName string, seed int64, options *models.GameOptions) (*Controller, error) {
+	controller := &Controller{
+		scoreStore: scoreStore,
+		playerName: playerName,
+		seed: seed,
+	}
 
 	state := models.CreateState()
+	applyOptionsErr := state.GetGame().ApplyOptions(options)
+	if applyOptionsErr != nil {
+		return nil, errors.WithStack(applyOptionsErr)
+	}
 	setWelcomeDataErr := state.SetWelcomeData()
 	if setWelcomeDataErr != nil {
 		return nil, errors.WithStack(setWelcomeDataErr)
 	}
+	_, addHeroErr := state.AddHero(LocalPlayerID)
+	if addHeroErr != nil {
+		return nil, errors.WithStack(addHeroErr)
+	}
 
 	screen := views.CreateScreen(24, 80)
 
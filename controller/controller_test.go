@@ -1,6 +1,9 @@
 package controller
 
 import (
+	"github.com/kjirou/gRPC-sample-net-game/leaderboard"
+	"github.com/kjirou/gRPC-sample-net-game/models"
+	"strings"
 	"testing"
 	"time"
 )
@@ -60,3 +63,202 @@ func TestController_CalculateIntervalToNextMainLoop_NotTD(t *testing.T) {
 		}
 	})
 }
+
+func TestMapStateModelToScreenProps_NotTD(t *testing.T) {
+	t.Run("レースモードでは、階数はプレイヤーごとになり、終了時に順位を表示する", func(t *testing.T) {
+		state := models.CreateState()
+		state.SetWelcomeData()
+		state.GetGame().SetMode(models.GameModeRace)
+		state.AddHero("a")
+		b, _ := state.AddHero("b")
+		b.IncrementClearedFloorCount()
+		state.GetGame().Finish()
+		screenProps, err := MapStateModelToScreenProps(state, "a")
+		if err != nil {
+			t.Fatal(err)
+		} else if screenProps.FloorNumber != 1 {
+			t.Fatal("自分の階数ではない")
+		} else if len(screenProps.SideLines) != 2 {
+			t.Fatal("順位の行数が違う")
+		} else if !strings.HasPrefix(screenProps.SideLines[0], "1. b ") {
+			t.Fatal("1 位が b ではない")
+		}
+	})
+
+	t.Run("通常モードでは順位を表示しない", func(t *testing.T) {
+		state := models.CreateState()
+		state.SetWelcomeData()
+		state.AddHero("a")
+		state.GetGame().Finish()
+		screenProps, _ := MapStateModelToScreenProps(state, "a")
+		if len(screenProps.SideLines) != 0 {
+			t.Fatal("順位を表示している")
+		}
+	})
+
+	t.Run("自分のヒーローの持ち物を順に表示する", func(t *testing.T) {
+		state := models.CreateState()
+		state.SetWelcomeData()
+		a, _ := state.AddHero("a")
+		a.GetInventory().Add(models.ObjectKindCompass)
+		a.GetInventory().Add(models.ObjectKindHourglass)
+		b, _ := state.AddHero("b")
+		b.GetInventory().Add(models.ObjectKindTeleporter)
+		screenProps, _ := MapStateModelToScreenProps(state, "a")
+		names := screenProps.InventoryItemNames
+		if len(names) != 2 || names[0] != "compass" || names[1] != "hourglass" {
+			t.Fatal("持ち物が違う")
+		}
+	})
+}
+
+// A leaderboard on memory.
+type testingScoreStore struct {
+	scores []*leaderboard.Score
+}
+
+func (store *testingScoreStore) SubmitScore(score *leaderboard.Score) error {
+	store.scores = append(store.scores, score)
+	return nil
+}
+
+func (store *testingScoreStore) GetTopScores(limit int) ([]*leaderboard.Score, error) {
+	return store.scores, nil
+}
+
+func TestController_Dispatch_NotTD(t *testing.T) {
+	t.Run("ゲームが終了したときに一度だけスコアを記録する", func(t *testing.T) {
+		store := &testingScoreStore{}
+		controller, err := CreateController(store, "alice", 0, &models.GameOptions{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		controller.HandleKeyPress('s', 0)
+		for i := 0; i < 3; i++ {
+			newState, handleMainLoopErr := controller.HandleMainLoop(time.Second*31)
+			if handleMainLoopErr != nil {
+				t.Fatal(handleMainLoopErr)
+			}
+			dispatchErr := controller.Dispatch(newState)
+			if dispatchErr != nil {
+				t.Fatal(dispatchErr)
+			}
+		}
+		if !controller.state.GetGame().IsFinished() {
+			t.Fatal("ゲームが終了していない")
+		} else if len(store.scores) != 1 {
+			t.Fatal("一度だけ記録されていない")
+		} else if store.scores[0].PlayerName != "alice" || store.scores[0].Floors != 1 {
+			t.Fatal("記録が違う")
+		}
+	})
+}
+
+// Campaign progress on memory.
+type testingProgressStore struct {
+	counts map[string]int
+}
+
+func (store *testingProgressStore) GetClearedLevelCount(campaignID string) (int, error) {
+	return store.counts[campaignID], nil
+}
+
+func (store *testingProgressStore) SaveClearedLevelCount(campaignID string, count int) error {
+	store.counts[campaignID] = count
+	return nil
+}
+
+func TestController_StartCampaign_NotTD(t *testing.T) {
+	createLevels := func(t *testing.T) []*models.Level {
+		levels := make([]*models.Level, 0)
+		for _, text := range []string{"#####\n#@.<#\n#####", "######\n#@..<#\n######", "#######\n#@...<#\n#######"} {
+			field, err := models.ParseFieldText(text)
+			if err != nil {
+				t.Fatal(err)
+			}
+			levels = append(levels, &models.Level{Name: "level", Field: field})
+		}
+		return levels
+	}
+	proceed := func(t *testing.T, controller *Controller, ch rune) {
+		controller.HandleKeyPress(ch, 0)
+		newState, err := controller.HandleMainLoop(time.Millisecond)
+		if err != nil {
+			t.Fatal(err)
+		}
+		dispatchErr := controller.Dispatch(newState)
+		if dispatchErr != nil {
+			t.Fatal(dispatchErr)
+		}
+	}
+
+	t.Run("保存した進捗の次のレベルから始め、クリアしたレベルを保存する", func(t *testing.T) {
+		scoreStore := &testingScoreStore{}
+		progressStore := &testingProgressStore{counts: map[string]int{"tutorial": 1}}
+		controller, _ := CreateController(scoreStore, LocalPlayerID, 0, &models.GameOptions{})
+		err := controller.StartCampaign("tutorial", createLevels(t), progressStore)
+		if err != nil {
+			t.Fatal(err)
+		}
+		proceed(t, controller, 's')
+		if controller.state.GetGame().GetFloorNumber() != 2 {
+			t.Fatal("2つ目のレベルから始まっていない")
+		}
+		screenProps, _ := MapStateModelToScreenProps(controller.state, LocalPlayerID)
+		if len(screenProps.SideLines) != 1 || screenProps.SideLines[0] != "Level: level" {
+			t.Fatal("レベル名を表示していない")
+		}
+
+		proceed(t, controller, 'l')
+		proceed(t, controller, 'l')
+		proceed(t, controller, 'l')
+		proceed(t, controller, 0)
+		if progressStore.counts["tutorial"] != 2 {
+			t.Fatal("進捗を保存していない")
+		}
+
+		proceed(t, controller, 'l')
+		proceed(t, controller, 'l')
+		proceed(t, controller, 'l')
+		proceed(t, controller, 'l')
+		proceed(t, controller, 0)
+		if progressStore.counts["tutorial"] != 3 || !controller.state.GetGame().HasClearedAllLevels() {
+			t.Fatal("キャンペーンを完了していない")
+		} else if len(scoreStore.scores) != 0 {
+			t.Fatal("スコアを記録している")
+		}
+		completedScreenProps, _ := MapStateModelToScreenProps(controller.state, LocalPlayerID)
+		if completedScreenProps.LankMessage != "Complete!" {
+			t.Fatal("完了を表示していない")
+		}
+
+		// 完了したキャンペーンは最初のレベルからやり直す。
+		proceed(t, controller, 's')
+		if controller.state.GetGame().GetFloorNumber() != 1 {
+			t.Fatal("最初のレベルから始まっていない")
+		}
+	})
+
+	t.Run("時間切れのときは失敗したレベルからやり直す", func(t *testing.T) {
+		progressStore := &testingProgressStore{counts: map[string]int{}}
+		controller, _ := CreateController(&testingScoreStore{}, LocalPlayerID, 0, &models.GameOptions{})
+		controller.StartCampaign("tutorial", createLevels(t), progressStore)
+		proceed(t, controller, 's')
+		proceed(t, controller, 'l')
+		proceed(t, controller, 'l')
+		proceed(t, controller, 0)
+		if controller.state.GetGame().GetFloorNumber() != 2 {
+			t.Fatal("2つ目のレベルに進んでいない")
+		}
+		controller.state.AlterExecutionTime(models.DefaultTimeLimit)
+		proceed(t, controller, 0)
+		proceed(t, controller, 0)
+		if !controller.state.GetGame().IsFinished() {
+			t.Fatal("時間切れになっていない")
+		}
+		proceed(t, controller, 's')
+		if controller.state.GetGame().GetFloorNumber() != 2 {
+			t.Fatal("失敗したレベルから始まっていない")
+		}
+	})
+}
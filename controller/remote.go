@@ -0,0 +1,311 @@
+package controller
+
+//
+// NOTE: RemoteController は、Models と Reducers をサーバ側に置いた場合のコントローラである。
+//       キー入力を Play RPC の入力へ変換して送り、サーバが生成した Props を Views へ渡すだけの薄い層になる。
+//
+
+import (
+	"context"
+	"github.com/kjirou/gRPC-sample-net-game/leaderboard"
+	"github.com/kjirou/gRPC-sample-net-game/models"
+	pb "github.com/kjirou/gRPC-sample-net-game/proto"
+	"github.com/kjirou/gRPC-sample-net-game/views"
+	"github.com/nsf/termbox-go"
+	"github.com/pkg/errors"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"io"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+func mapScreenPropsMessageToScreenProps(message *pb.ScreenProps) *views.ScreenProps {
+	fieldCells := make([][]*views.ScreenCellProps, len(message.GetFieldCells()))
+	for y, rowMessage := range message.GetFieldCells() {
+		cellsRow := make([]*views.ScreenCellProps, len(rowMessage.GetCells()))
+		for x, cellMessage := range rowMessage.GetCells() {
+			cellsRow[x] = &views.ScreenCellProps{
+				Symbol: rune(cellMessage.GetSymbol()),
+				Foreground: termbox.Attribute(cellMessage.GetForeground()),
+				Background: termbox.Attribute(cellMessage.GetBackground()),
+			}
+		}
+		fieldCells[y] = cellsRow
+	}
+	return &views.ScreenProps{
+		FieldCells: fieldCells,
+		FloorNumber: int(message.GetFloorNumber()),
+		LankMessage: message.GetLankMessage(),
+		LankMessageForeground: termbox.Attribute(message.GetLankMessageForeground()),
+		SideLines: message.GetSideLines(),
+		RemainingTime: message.GetRemainingTime(),
+		Seed: message.GetSeed(),
+		DigCharges: int(message.GetDigCharges()),
+		InventoryItemNames: message.GetInventoryItemNames(),
+		KeyNames: message.GetKeyNames(),
+	}
+}
+
+func mapScoreMessageToScore(message *pb.Score) *leaderboard.Score {
+	mode := models.GameModeStandard
+	switch message.GetMode() {
+	case pb.GameMode_GAME_MODE_RACE:
+		mode = models.GameModeRace
+	case pb.GameMode_GAME_MODE_COOP:
+		mode = models.GameModeCoop
+	}
+	return &leaderboard.Score{
+		PlayerName: message.GetPlayerName(),
+		Floors: int(message.GetFloors()),
+		AchievedAt: time.Unix(message.GetAchievedAt(), 0),
+		Seed: message.GetSeed(),
+		Mode: mode,
+	}
+}
+
+// Convert a key input to an input of the Play RPC.
+// Only this function knows termbox's keys, so they never cross the wire.
+func mapKeyInputsToPlayInputType(ch rune, key termbox.Key) pb.PlayInputType {
+	switch {
+	// Start or restart a game.
+	case ch == 's':
+		return pb.PlayInputType_PLAY_INPUT_TYPE_START_OR_RESTART_GAME
+	// Move the hero.
+	case key == termbox.KeyArrowUp || ch == 'k':
+		return pb.PlayInputType_PLAY_INPUT_TYPE_WALK_HERO_UP
+	case key == termbox.KeyArrowRight || ch == 'l':
+		return pb.PlayInputType_PLAY_INPUT_TYPE_WALK_HERO_RIGHT
+	case key == termbox.KeyArrowDown || ch == 'j':
+		return pb.PlayInputType_PLAY_INPUT_TYPE_WALK_HERO_DOWN
+	case key == termbox.KeyArrowLeft || ch == 'h':
+		return pb.PlayInputType_PLAY_INPUT_TYPE_WALK_HERO_LEFT
+	// Dig a wall next to the hero.
+	case ch == 'K':
+		return pb.PlayInputType_PLAY_INPUT_TYPE_DIG_WALL_UP
+	case ch == 'L':
+		return pb.PlayInputType_PLAY_INPUT_TYPE_DIG_WALL_RIGHT
+	case ch == 'J':
+		return pb.PlayInputType_PLAY_INPUT_TYPE_DIG_WALL_DOWN
+	case ch == 'H':
+		return pb.PlayInputType_PLAY_INPUT_TYPE_DIG_WALL_LEFT
+	// Use an item in the inventory by its number.
+	case ch >= '1' && ch < '1'+models.InventoryCapacity:
+		return pb.PlayInputType_PLAY_INPUT_TYPE_USE_ITEM_1 + pb.PlayInputType(ch-'1')
+	}
+	return pb.PlayInputType_PLAY_INPUT_TYPE_UNSPECIFIED
+}
+
+type RemoteController struct {
+	inputtedCharacter rune
+	inputtedKey termbox.Key
+	gameClient pb.GameServiceClient
+	playerID string
+	roomID string
+	playStream pb.GameService_PlayClient
+	// The sequence number of the last sent input.
+	sequenceNumber int64
+	// The sequence number of the last input that the server applied.
+	// It is accessed atomically, because it is updated by `ReceivePlayResponses` in another goroutine.
+	lastAcknowledgedSequenceNumber int64
+	// It is true while the score of the finished game has been submitted.
+	// It is touched only by `ReceiveScreens`.
+	scoreSubmitted bool
+	// It guards the `screen`, the `showsHighScores` and the `highScoresProps`,
+	// because the main loop and `ReceiveScreens` run in different goroutines.
+	mutex sync.Mutex
+	showsHighScores bool
+	highScoresProps *views.HighScoresProps
+	screen *views.Screen
+}
+
+func (controller *RemoteController) GetScreen() *views.Screen {
+	return controller.screen
+}
+
+func (controller *RemoteController) GetLastAcknowledgedSequenceNumber() int64 {
+	return atomic.LoadInt64(&controller.lastAcknowledgedSequenceNumber)
+}
+
+func (controller *RemoteController) setKeyInputs(ch rune, key termbox.Key) {
+	controller.inputtedCharacter = ch
+	controller.inputtedKey = key
+}
+
+func (controller *RemoteController) resetKeyInputs() {
+	controller.setKeyInputs(0, 0)
+}
+
+// Send the last key input to the server.
+// The screen is not rendered here, because the server pushes it via `ReceiveScreens`.
+func (controller *RemoteController) HandleMainLoop() error {
+	ch := controller.inputtedCharacter
+	key := controller.inputtedKey
+	controller.resetKeyInputs()
+
+	if ch == 'r' {
+		controller.toggleHighScores(context.Background())
+		return nil
+	}
+
+	inputType := mapKeyInputsToPlayInputType(ch, key)
+	if inputType == pb.PlayInputType_PLAY_INPUT_TYPE_UNSPECIFIED {
+		return nil
+	}
+	controller.sequenceNumber++
+	err := controller.playStream.Send(&pb.PlayRequest{
+		SequenceNumber: controller.sequenceNumber,
+		InputType: inputType,
+		PlayerId: controller.playerID,
+		RoomId: controller.roomID,
+	})
+	return errors.WithStack(err)
+}
+
+// Receive acknowledgements of sent inputs until the stream ends.
+func (controller *RemoteController) ReceivePlayResponses() error {
+	for {
+		response, err := controller.playStream.Recv()
+		if err == io.EOF {
+			return nil
+		} else if err != nil {
+			return errors.WithStack(err)
+		}
+		atomic.StoreInt64(&controller.lastAcknowledgedSequenceNumber, response.GetSequenceNumber())
+	}
+}
+
+// Open or close the high-score screen. It is reflected in the next pushed screen.
+func (controller *RemoteController) toggleHighScores(ctx context.Context) {
+	controller.mutex.Lock()
+	showsHighScores := !controller.showsHighScores
+	controller.showsHighScores = showsHighScores
+	controller.mutex.Unlock()
+	if !showsHighScores {
+		return
+	}
+	highScoresProps := &views.HighScoresProps{}
+	response, err := controller.gameClient.GetTopScores(ctx, &pb.GetTopScoresRequest{Limit: HighScoresLimit})
+	if err != nil {
+		// A broken leaderboard should not stop the game.
+		highScoresProps.Message = "Failed to load scores."
+	} else {
+		scores := make([]*leaderboard.Score, len(response.GetScores()))
+		for index, scoreMessage := range response.GetScores() {
+			scores[index] = mapScoreMessageToScore(scoreMessage)
+		}
+		highScoresProps = MapScoresToHighScoresProps(scores)
+	}
+	controller.mutex.Lock()
+	controller.highScoresProps = highScoresProps
+	controller.mutex.Unlock()
+}
+
+func (controller *RemoteController) render(screenPropsMessage *pb.ScreenProps) {
+	controller.mutex.Lock()
+	defer controller.mutex.Unlock()
+	if controller.showsHighScores && controller.highScoresProps != nil {
+		controller.screen.RenderHighScores(controller.highScoresProps)
+		return
+	}
+	controller.screen.Render(mapScreenPropsMessageToScreenProps(screenPropsMessage))
+}
+
+// Submit the score once when the game has finished.
+func (controller *RemoteController) submitScoreIfFinished(ctx context.Context, screenPropsMessage *pb.ScreenProps) error {
+	if !screenPropsMessage.GetIsGameFinished() {
+		controller.scoreSubmitted = false
+		return nil
+	} else if controller.scoreSubmitted {
+		return nil
+	}
+	_, err := controller.gameClient.SubmitScore(ctx, &pb.SubmitScoreRequest{
+		RoomId: controller.roomID,
+		PlayerId: controller.playerID,
+	})
+	// It has been submitted by another connection of the same player.
+	if status.Code(err) == codes.AlreadyExists {
+		err = nil
+	}
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	controller.scoreSubmitted = true
+	return nil
+}
+
+func (controller *RemoteController) FetchScreen(ctx context.Context) error {
+	response, err := controller.gameClient.GetScreen(ctx, &pb.GetScreenRequest{PlayerId: controller.playerID, RoomId: controller.roomID})
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	controller.render(response.GetScreenProps())
+	return nil
+}
+
+// Render screens that the server pushes until the stream ends.
+// The `onRender` is called after each rendering.
+func (controller *RemoteController) ReceiveScreens(ctx context.Context, onRender func()) error {
+	stream, err := controller.gameClient.StreamState(ctx, &pb.StreamStateRequest{PlayerId: controller.playerID, RoomId: controller.roomID})
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	for {
+		response, recvErr := stream.Recv()
+		if recvErr == io.EOF {
+			return nil
+		} else if recvErr != nil {
+			return errors.WithStack(recvErr)
+		}
+		submitScoreErr := controller.submitScoreIfFinished(ctx, response.GetScreenProps())
+		if submitScoreErr != nil {
+			return submitScoreErr
+		}
+		controller.render(response.GetScreenProps())
+		onRender()
+	}
+}
+
+func (controller *RemoteController) HandleKeyPress(ch rune, key termbox.Key) {
+	controller.setKeyInputs(ch, key)
+}
+
+// Leave the room. The controller can not be used after that.
+func (controller *RemoteController) Leave(ctx context.Context) error {
+	_, err := controller.gameClient.LeaveRoom(ctx, &pb.LeaveRoomRequest{
+		RoomId: controller.roomID,
+		PlayerId: controller.playerID,
+	})
+	return errors.WithStack(err)
+}
+
+// Create a controller that has joined the room as the player.
+func CreateRemoteController(
+	ctx context.Context, gameClient pb.GameServiceClient, roomID string, playerID string) (*RemoteController, error) {
+	controller := &RemoteController{
+		gameClient: gameClient,
+		playerID: playerID,
+		roomID: roomID,
+		screen: views.CreateScreen(24, 80),
+	}
+	controller.resetKeyInputs()
+
+	_, joinErr := gameClient.JoinRoom(ctx, &pb.JoinRoomRequest{RoomId: roomID, PlayerId: playerID})
+	if joinErr != nil {
+		return nil, errors.WithStack(joinErr)
+	}
+
+	playStream, playErr := gameClient.Play(ctx)
+	if playErr != nil {
+		return nil, errors.WithStack(playErr)
+	}
+	controller.playStream = playStream
+
+	fetchScreenErr := controller.FetchScreen(ctx)
+	if fetchScreenErr != nil {
+		return nil, errors.WithStack(fetchScreenErr)
+	}
+
+	return controller, nil
+}
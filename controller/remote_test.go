@@ -0,0 +1,44 @@
+package controller
+
+import (
+	pb "github.com/kjirou/gRPC-sample-net-game/proto"
+	"github.com/nsf/termbox-go"
+	"testing"
+)
+
+func Test_mapKeyInputsToPlayInputType_NotTD(t *testing.T) {
+	t.Run("矢印キーと hjkl は同じ移動の入力になる", func(t *testing.T) {
+		testCases := []struct {
+			ch rune
+			key termbox.Key
+			want pb.PlayInputType
+		}{
+			{ch: 0, key: termbox.KeyArrowUp, want: pb.PlayInputType_PLAY_INPUT_TYPE_WALK_HERO_UP},
+			{ch: 'k', key: 0, want: pb.PlayInputType_PLAY_INPUT_TYPE_WALK_HERO_UP},
+			{ch: 0, key: termbox.KeyArrowRight, want: pb.PlayInputType_PLAY_INPUT_TYPE_WALK_HERO_RIGHT},
+			{ch: 'l', key: 0, want: pb.PlayInputType_PLAY_INPUT_TYPE_WALK_HERO_RIGHT},
+			{ch: 0, key: termbox.KeyArrowDown, want: pb.PlayInputType_PLAY_INPUT_TYPE_WALK_HERO_DOWN},
+			{ch: 'j', key: 0, want: pb.PlayInputType_PLAY_INPUT_TYPE_WALK_HERO_DOWN},
+			{ch: 0, key: termbox.KeyArrowLeft, want: pb.PlayInputType_PLAY_INPUT_TYPE_WALK_HERO_LEFT},
+			{ch: 'h', key: 0, want: pb.PlayInputType_PLAY_INPUT_TYPE_WALK_HERO_LEFT},
+			{ch: 'K', key: 0, want: pb.PlayInputType_PLAY_INPUT_TYPE_DIG_WALL_UP},
+			{ch: 'L', key: 0, want: pb.PlayInputType_PLAY_INPUT_TYPE_DIG_WALL_RIGHT},
+			{ch: 'J', key: 0, want: pb.PlayInputType_PLAY_INPUT_TYPE_DIG_WALL_DOWN},
+			{ch: 'H', key: 0, want: pb.PlayInputType_PLAY_INPUT_TYPE_DIG_WALL_LEFT},
+			{ch: '1', key: 0, want: pb.PlayInputType_PLAY_INPUT_TYPE_USE_ITEM_1},
+			{ch: '5', key: 0, want: pb.PlayInputType_PLAY_INPUT_TYPE_USE_ITEM_5},
+		}
+		for _, testCase := range testCases {
+			if got := mapKeyInputsToPlayInputType(testCase.ch, testCase.key); got != testCase.want {
+				t.Fatalf("ch=%q, key=%v の入力が %v ではなく %v になる", testCase.ch, testCase.key, testCase.want, got)
+			}
+		}
+	})
+
+	t.Run("割り当てのないキーは UNSPECIFIED になる", func(t *testing.T) {
+		if mapKeyInputsToPlayInputType('x', 0) != pb.PlayInputType_PLAY_INPUT_TYPE_UNSPECIFIED ||
+			mapKeyInputsToPlayInputType('6', 0) != pb.PlayInputType_PLAY_INPUT_TYPE_UNSPECIFIED {
+			t.Fatal("UNSPECIFIED ではない")
+		}
+	})
+}
@@ -0,0 +1,143 @@
+package controller
+
+//
+// NOTE: SpectatorController は、ヒーローを持たずに部屋を観戦するためのコントローラである。
+//       フィールド全体と、追従しているプレイヤーの画面とを切り替えて表示できる。
+//
+
+import (
+	"context"
+	"fmt"
+	pb "github.com/kjirou/gRPC-sample-net-game/proto"
+	"github.com/kjirou/gRPC-sample-net-game/views"
+	"github.com/nsf/termbox-go"
+	"github.com/pkg/errors"
+	"io"
+	"sync"
+)
+
+func mapSpectateResponseToScreenProps(response *pb.SpectateResponse, showsWholeField bool) *views.ScreenProps {
+	sideLines := make([]string, 0)
+	for _, player := range response.GetPlayers() {
+		marker := " "
+		if player.GetPlayerId() == response.GetFollowedPlayerId() {
+			marker = ">"
+		}
+		reached := ""
+		if player.GetHasReachedUpstairs() {
+			reached = " <"
+		}
+		sideLines = append(sideLines, fmt.Sprintf("%s %-12.12s (%2d,%2d) %2dF%s",
+			marker, player.GetPlayerId(), player.GetY(), player.GetX(), player.GetClearedFloorCount()+1, reached))
+	}
+	sideLines = append(sideLines, "", "[n/p] Follow next/previous", "[f] Toggle whole field")
+
+	followedScreenProps := response.GetFollowedScreenProps()
+	if !showsWholeField && followedScreenProps != nil {
+		screenProps := mapScreenPropsMessageToScreenProps(followedScreenProps)
+		screenProps.SideLines = append(sideLines, screenProps.SideLines...)
+		return screenProps
+	}
+
+	fieldCells := make([][]*views.ScreenCellProps, 0)
+	for _, rowMessage := range response.GetFieldCells() {
+		cellsRow := make([]*views.ScreenCellProps, 0)
+		for _, cellMessage := range rowMessage.GetCells() {
+			cellsRow = append(cellsRow, &views.ScreenCellProps{
+				Symbol: rune(cellMessage.GetSymbol()),
+				Foreground: termbox.Attribute(cellMessage.GetForeground()),
+				Background: termbox.Attribute(cellMessage.GetBackground()),
+			})
+		}
+		fieldCells = append(fieldCells, cellsRow)
+	}
+	return &views.ScreenProps{
+		FieldCells: fieldCells,
+		FloorNumber: int(response.GetFloorNumber()),
+		LankMessage: "Spectating",
+		LankMessageForeground: termbox.ColorCyan,
+		SideLines: sideLines,
+		RemainingTime: response.GetRemainingTime(),
+	}
+}
+
+type SpectatorController struct {
+	// It guards the fields below that are touched by both the key event loop and the receiving loop.
+	mutex sync.Mutex
+	lastResponse *pb.SpectateResponse
+	showsWholeField bool
+	spectateStream pb.GameService_SpectateClient
+	screen *views.Screen
+}
+
+func (controller *SpectatorController) GetScreen() *views.Screen {
+	return controller.screen
+}
+
+// Request to follow the player who is the `delta`-th next to the current one.
+// The `mutex` must be locked by the caller.
+func (controller *SpectatorController) followNextPlayer(delta int) error {
+	if controller.lastResponse == nil || len(controller.lastResponse.GetPlayers()) == 0 {
+		return nil
+	}
+	players := controller.lastResponse.GetPlayers()
+	currentIndex := 0
+	for index, player := range players {
+		if player.GetPlayerId() == controller.lastResponse.GetFollowedPlayerId() {
+			currentIndex = index
+		}
+	}
+	nextIndex := ((currentIndex + delta) % len(players) + len(players)) % len(players)
+	err := controller.spectateStream.Send(&pb.SpectateRequest{FollowPlayerId: players[nextIndex].GetPlayerId()})
+	return errors.WithStack(err)
+}
+
+// The change is reflected in the next snapshot.
+// Errors of sending are ignored here, because a broken stream is reported by `ReceiveSnapshots`.
+func (controller *SpectatorController) HandleKeyPress(ch rune, key termbox.Key) {
+	controller.mutex.Lock()
+	defer controller.mutex.Unlock()
+	switch {
+	case ch == 'n':
+		controller.followNextPlayer(1)
+	case ch == 'p':
+		controller.followNextPlayer(-1)
+	case ch == 'f':
+		controller.showsWholeField = !controller.showsWholeField
+	}
+}
+
+// Render snapshots that the server pushes until the stream ends.
+// The `onRender` is called after each rendering.
+func (controller *SpectatorController) ReceiveSnapshots(onRender func()) error {
+	for {
+		response, err := controller.spectateStream.Recv()
+		if err == io.EOF {
+			return nil
+		} else if err != nil {
+			return errors.WithStack(err)
+		}
+		controller.mutex.Lock()
+		controller.lastResponse = response
+		controller.screen.Render(mapSpectateResponseToScreenProps(response, controller.showsWholeField))
+		controller.mutex.Unlock()
+		onRender()
+	}
+}
+
+// Create a controller that spectates the room.
+func CreateSpectatorController(
+	ctx context.Context, gameClient pb.GameServiceClient, roomID string) (*SpectatorController, error) {
+	spectateStream, err := gameClient.Spectate(ctx)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	sendErr := spectateStream.Send(&pb.SpectateRequest{RoomId: roomID})
+	if sendErr != nil {
+		return nil, errors.WithStack(sendErr)
+	}
+	return &SpectatorController{
+		spectateStream: spectateStream,
+		screen: views.CreateScreen(24, 80),
+	}, nil
+}
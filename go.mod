@@ -6,4 +6,6 @@ require (
 	github.com/mattn/go-runewidth v0.0.9 // indirect
 	github.com/nsf/termbox-go v0.0.0-20200204031403-4d2b513ad8be
 	github.com/pkg/errors v0.9.1
+	google.golang.org/grpc v1.43.0
+	google.golang.org/protobuf v1.27.1
 )
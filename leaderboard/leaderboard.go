@@ -0,0 +1,131 @@
+package leaderboard
+
+//
+// The "leaderboard" package keeps scores of finished games beyond the process.
+//
+
+import (
+	"github.com/kjirou/gRPC-sample-net-game/models"
+	"github.com/kjirou/gRPC-sample-net-game/utils"
+	"github.com/pkg/errors"
+	"sort"
+	"sync"
+	"time"
+)
+
+// The file name of the store in the home directory.
+const DefaultFileName = ".tower-of-go-scores.json"
+
+type Score struct {
+	PlayerName string `json:"playerName"`
+	// The number of the floor that the player reached.
+	Floors int `json:"floors"`
+	AchievedAt time.Time `json:"achievedAt"`
+	// The seed of the game's mazes. The same seed reproduces the same floors.
+	Seed int64 `json:"seed"`
+	Mode models.GameMode `json:"mode"`
+}
+
+type Store interface {
+	SubmitScore(score *Score) error
+	// Return scores in descending order of floors. Scores achieved earlier win ties.
+	GetTopScores(limit int) ([]*Score, error)
+}
+
+// Sort scores in the order of `GetTopScores`.
+func SortScores(scores []*Score) {
+	sort.SliceStable(scores, func(a, b int) bool {
+		if scores[a].Floors != scores[b].Floors {
+			return scores[a].Floors > scores[b].Floors
+		}
+		return scores[a].AchievedAt.Before(scores[b].AchievedAt)
+	})
+}
+
+// Create the score of the player from the state of a finished game.
+// In the race mode, the player's own floor is the score.
+func CreateScoreOfHero(state *models.State, playerID string, achievedAt time.Time) (*Score, error) {
+	game := state.GetGame()
+	if !game.IsFinished() {
+		return nil, errors.New("The game has not finished yet.")
+	}
+	for _, hero := range state.GetHeroes() {
+		if hero.GetPlayerID() != playerID {
+			continue
+		}
+		floors := game.GetFloorNumber()
+		if game.GetMode() == models.GameModeRace {
+			floors = hero.GetClearedFloorCount() + 1
+		}
+		return &Score{
+			PlayerName: playerID,
+			Floors: floors,
+			AchievedAt: achievedAt,
+			Seed: game.GetSeed(),
+			Mode: game.GetMode(),
+		}, nil
+	}
+	return nil, errors.Errorf("The hero of the %q player does not exist.", playerID)
+}
+
+// A store that saves all scores to a JSON file.
+type FileStore struct {
+	// It guards the file from concurrent RPCs.
+	mutex sync.Mutex
+	path string
+}
+
+func (store *FileStore) GetPath() string {
+	return store.path
+}
+
+// The `mutex` must be locked by the caller.
+func (store *FileStore) load() ([]*Score, error) {
+	scores := make([]*Score, 0)
+	err := utils.ReadJSONFile(store.path, &scores)
+	if err != nil {
+		return nil, err
+	}
+	return scores, nil
+}
+
+func (store *FileStore) SubmitScore(score *Score) error {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+	scores, err := store.load()
+	if err != nil {
+		return err
+	}
+	scores = append(scores, score)
+	SortScores(scores)
+	return utils.WriteJSONFile(store.path, scores)
+}
+
+func (store *FileStore) GetTopScores(limit int) ([]*Score, error) {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+	scores, err := store.load()
+	if err != nil {
+		return nil, err
+	}
+	SortScores(scores)
+	if len(scores) > limit {
+		scores = scores[:limit]
+	}
+	return scores, nil
+}
+
+func CreateFileStore(path string) *FileStore {
+	return &FileStore{
+		path: path,
+	}
+}
+
+// Create a store of the `DefaultFileName` in the home directory.
+func CreateDefaultFileStore() (*FileStore, error) {
+	path, err := utils.GetHomeFilePath(DefaultFileName)
+	if err != nil {
+		return nil, err
+	}
+	return CreateFileStore(path), nil
+}
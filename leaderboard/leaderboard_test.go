@@ -0,0 +1,138 @@
+package leaderboard
+
+import (
+	"github.com/kjirou/gRPC-sample-net-game/models"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func createTestingFileStore(t *testing.T) *FileStore {
+	directory, err := ioutil.TempDir("", "leaderboard")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		os.RemoveAll(directory)
+	})
+	return CreateFileStore(filepath.Join(directory, DefaultFileName))
+}
+
+func TestFileStore_GetTopScores_NotTD(t *testing.T) {
+	achievedAt := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	t.Run("ファイルが存在しないときは空を返す", func(t *testing.T) {
+		store := createTestingFileStore(t)
+		scores, err := store.GetTopScores(10)
+		if err != nil {
+			t.Fatal(err)
+		} else if len(scores) != 0 {
+			t.Fatal("空ではない")
+		}
+	})
+
+	t.Run("階数の降順、同じ階数なら先に達成した順で返す", func(t *testing.T) {
+		store := createTestingFileStore(t)
+		store.SubmitScore(&Score{PlayerName: "a", Floors: 3, AchievedAt: achievedAt.Add(time.Hour)})
+		store.SubmitScore(&Score{PlayerName: "b", Floors: 5, AchievedAt: achievedAt})
+		store.SubmitScore(&Score{PlayerName: "c", Floors: 3, AchievedAt: achievedAt})
+		scores, err := store.GetTopScores(10)
+		if err != nil {
+			t.Fatal(err)
+		} else if len(scores) != 3 {
+			t.Fatal("件数が違う")
+		} else if scores[0].PlayerName != "b" || scores[1].PlayerName != "c" || scores[2].PlayerName != "a" {
+			t.Fatal("順序が違う")
+		}
+	})
+
+	t.Run("件数を制限できる", func(t *testing.T) {
+		store := createTestingFileStore(t)
+		store.SubmitScore(&Score{PlayerName: "a", Floors: 1, AchievedAt: achievedAt})
+		store.SubmitScore(&Score{PlayerName: "b", Floors: 2, AchievedAt: achievedAt})
+		scores, _ := store.GetTopScores(1)
+		if len(scores) != 1 || scores[0].PlayerName != "b" {
+			t.Fatal("制限されていない")
+		}
+	})
+
+	t.Run("別のストアから同じファイルを読める", func(t *testing.T) {
+		store := createTestingFileStore(t)
+		store.SubmitScore(&Score{
+			PlayerName: "a",
+			Floors: 4,
+			AchievedAt: achievedAt,
+			Seed: 123,
+			Mode: models.GameModeRace,
+		})
+		scores, err := CreateFileStore(store.GetPath()).GetTopScores(10)
+		if err != nil {
+			t.Fatal(err)
+		} else if len(scores) != 1 {
+			t.Fatal("保存されていない")
+		} else if scores[0].Floors != 4 || !scores[0].AchievedAt.Equal(achievedAt) ||
+			scores[0].Seed != 123 || scores[0].Mode != models.GameModeRace {
+			t.Fatal("保存された内容が違う")
+		}
+	})
+
+	t.Run("壊れたファイルはエラーを返す", func(t *testing.T) {
+		store := createTestingFileStore(t)
+		ioutil.WriteFile(store.GetPath(), []byte("{"), 0644)
+		_, err := store.GetTopScores(10)
+		if err == nil {
+			t.Fatal("エラーを返さない")
+		}
+	})
+}
+
+func TestCreateScoreOfHero_NotTD(t *testing.T) {
+	createState := func(mode models.GameMode) *models.State {
+		state := models.CreateState()
+		state.SetWelcomeData()
+		state.GetGame().SetMode(mode)
+		state.AddHero("a")
+		return state
+	}
+
+	t.Run("終了していないゲームではエラーを返す", func(t *testing.T) {
+		state := createState(models.GameModeStandard)
+		_, err := CreateScoreOfHero(state, "a", time.Now())
+		if err == nil {
+			t.Fatal("エラーを返さない")
+		}
+	})
+
+	t.Run("標準モードではゲームの階数を記録する", func(t *testing.T) {
+		state := createState(models.GameModeStandard)
+		state.GetGame().IncrementFloorNumber()
+		state.GetGame().Finish()
+		score, err := CreateScoreOfHero(state, "a", time.Now())
+		if err != nil {
+			t.Fatal(err)
+		} else if score.Floors != 2 || score.PlayerName != "a" || score.Mode != models.GameModeStandard {
+			t.Fatal("記録が違う")
+		}
+	})
+
+	t.Run("レースモードではヒーローの階数を記録する", func(t *testing.T) {
+		state := createState(models.GameModeRace)
+		state.GetGame().IncrementFloorNumber()
+		state.GetGame().Finish()
+		score, _ := CreateScoreOfHero(state, "a", time.Now())
+		if score.Floors != 1 {
+			t.Fatal("ヒーローの階数ではない")
+		}
+	})
+
+	t.Run("存在しないプレイヤーはエラーを返す", func(t *testing.T) {
+		state := createState(models.GameModeStandard)
+		state.GetGame().Finish()
+		_, err := CreateScoreOfHero(state, "b", time.Now())
+		if err == nil {
+			t.Fatal("エラーを返さない")
+		}
+	})
+}
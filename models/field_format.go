@@ -0,0 +1,220 @@
+package models
+
+//
+// Fields are saved as text or JSON, e.g. for hand-crafted floors and test fixtures.
+// Actors are not saved, because heroes belong to players and monsters are placed on each floor.
+//
+
+import (
+	"encoding/json"
+	"github.com/kjirou/gRPC-sample-net-game/utils"
+	"github.com/pkg/errors"
+	"strings"
+)
+
+// The glyph of the entrance in the text format. Other glyphs are the ones of `ObjectKindDefinition`s.
+const FieldGlyphEntrance = '@'
+
+// Actors are not saved, so any other kinds can be saved on their own layers.
+func isSavableObjectKind(kind ObjectKind, layer ObjectLayer) bool {
+	return !kind.IsActor() && kind.CanBeOn(layer)
+}
+
+// Find the savable kind of the glyph. Glyphs of saved kinds must be unique.
+func findSavableObjectKindByGlyph(glyph rune) (ObjectKind, bool) {
+	for _, kind := range GetObjectKinds() {
+		if !kind.IsActor() && kind.GetDefinition().Glyph == glyph {
+			return kind, true
+		}
+	}
+	return ObjectKindEmpty, false
+}
+
+// Create a field from kinds of each element. Heroes are placed near the `entrance` later.
+// The `floorObjectKinds` must have exactly one `ObjectKindUpstairs`.
+func createFieldFromKinds(
+	objectKinds [][]ObjectKind, floorObjectKinds [][]ObjectKind, entrance *utils.MatrixPosition) (*Field, error) {
+	rowLength := len(objectKinds)
+	if rowLength == 0 || len(objectKinds[0]) == 0 {
+		return nil, errors.New("The field is empty.")
+	} else if len(floorObjectKinds) != rowLength {
+		return nil, errors.New("The numbers of rows of objects and floor objects are different.")
+	}
+	columnLength := len(objectKinds[0])
+	field := createField(rowLength, columnLength)
+	var upstairs *utils.MatrixPosition
+	for y := 0; y < rowLength; y++ {
+		if len(objectKinds[y]) != columnLength || len(floorObjectKinds[y]) != columnLength {
+			return nil, errors.Errorf("The row %d does not have %d columns.", y, columnLength)
+		}
+		for x := 0; x < columnLength; x++ {
+			objectKind := objectKinds[y][x]
+			floorObjectKind := floorObjectKinds[y][x]
+			if !isSavableObjectKind(objectKind, ObjectLayerObject) {
+				return nil, errors.Errorf("The %q object at Y=%d, X=%d is invalid.", objectKind.GetName(), y, x)
+			} else if !isSavableObjectKind(floorObjectKind, ObjectLayerFloor) {
+				return nil, errors.Errorf(
+					"The %q floor object at Y=%d, X=%d is invalid.", floorObjectKind.GetName(), y, x)
+			}
+			if floorObjectKind == ObjectKindUpstairs {
+				if upstairs != nil {
+					return nil, errors.New("There are multiple upstairs.")
+				}
+				upstairs = &utils.MatrixPosition{Y: y, X: x}
+			}
+			element := field.matrix[y][x]
+			element.UpdateObjectKind(objectKind)
+			element.UpdateFloorObjectKind(floorObjectKind)
+		}
+	}
+	if upstairs == nil {
+		return nil, errors.New("There is no upstairs.")
+	} else if entrance == nil {
+		return nil, errors.New("There is no entrance.")
+	}
+	entranceElement, entranceElementOk := field.At(entrance)
+	if !entranceElementOk || !entranceElement.CanBeEntered() {
+		return nil, errors.Errorf("The entrance at %v is not an empty element.", *entrance)
+	}
+	relocateErr := field.RelocateEntranceAndUpstairs(entrance, upstairs)
+	if relocateErr != nil {
+		return nil, relocateErr
+	}
+	return field, nil
+}
+
+// Serialize the field to lines of glyphs, e.g. "#@.<#".
+// An element that has both an object and a floor object is saved as the object, as well as rendering.
+func (field *Field) ToText() string {
+	lines := make([]string, 0, field.MeasureRowLength())
+	for y, row := range field.matrix {
+		line := make([]rune, 0, len(row))
+		for x, element := range row {
+			glyph := ObjectKindEmpty.GetDefinition().Glyph
+			switch {
+			case !element.IsObjectEmpty() && !element.GetObjectKind().IsActor():
+				glyph = element.GetObjectKind().GetDefinition().Glyph
+			case element.GetFloorObjectKind() != ObjectKindEmpty:
+				glyph = element.GetFloorObjectKind().GetDefinition().Glyph
+			case y == field.entrancePosition.GetY() && x == field.entrancePosition.GetX():
+				glyph = FieldGlyphEntrance
+			}
+			line = append(line, glyph)
+		}
+		lines = append(lines, string(line))
+	}
+	return strings.Join(lines, "\n") + "\n"
+}
+
+// Load a field from the format of `Field.ToText`.
+// It must have exactly one entrance and one upstairs. Blank lines at both ends are ignored.
+func ParseFieldText(text string) (*Field, error) {
+	lines := strings.Split(strings.Trim(strings.ReplaceAll(text, "\r\n", "\n"), "\n"), "\n")
+	objectKinds := make([][]ObjectKind, len(lines))
+	floorObjectKinds := make([][]ObjectKind, len(lines))
+	var entrance *utils.MatrixPosition
+	for y, line := range lines {
+		objectKinds[y] = make([]ObjectKind, 0, len(line))
+		floorObjectKinds[y] = make([]ObjectKind, 0, len(line))
+		for x, glyph := range []rune(line) {
+			objectKind := ObjectKindEmpty
+			floorObjectKind := ObjectKindEmpty
+			if glyph == FieldGlyphEntrance {
+				if entrance != nil {
+					return nil, errors.New("There are multiple entrances.")
+				}
+				entrance = &utils.MatrixPosition{Y: y, X: x}
+			} else {
+				kind, ok := findSavableObjectKindByGlyph(glyph)
+				if !ok {
+					return nil, errors.Errorf("The %q glyph at line %d, column %d is invalid.", glyph, y+1, x+1)
+				} else if kind.CanBeOn(ObjectLayerObject) {
+					objectKind = kind
+				} else {
+					floorObjectKind = kind
+				}
+			}
+			objectKinds[y] = append(objectKinds[y], objectKind)
+			floorObjectKinds[y] = append(floorObjectKinds[y], floorObjectKind)
+		}
+	}
+	return createFieldFromKinds(objectKinds, floorObjectKinds, entrance)
+}
+
+type fieldPositionJSON struct {
+	Y int `json:"y"`
+	X int `json:"x"`
+}
+
+type fieldJSON struct {
+	// Names of object kinds in rows, e.g. [["wall", "empty"]]. Actors are saved as "empty".
+	Objects [][]string `json:"objects"`
+	FloorObjects [][]string `json:"floorObjects"`
+	Entrance *fieldPositionJSON `json:"entrance"`
+}
+
+func (field *Field) MarshalJSON() ([]byte, error) {
+	data := &fieldJSON{
+		Objects: make([][]string, field.MeasureRowLength()),
+		FloorObjects: make([][]string, field.MeasureRowLength()),
+		Entrance: &fieldPositionJSON{
+			Y: field.entrancePosition.GetY(),
+			X: field.entrancePosition.GetX(),
+		},
+	}
+	for y, row := range field.matrix {
+		data.Objects[y] = make([]string, len(row))
+		data.FloorObjects[y] = make([]string, len(row))
+		for x, element := range row {
+			objectKind := element.GetObjectKind()
+			if objectKind.IsActor() {
+				objectKind = ObjectKindEmpty
+			}
+			data.Objects[y][x] = objectKind.GetName()
+			data.FloorObjects[y][x] = element.GetFloorObjectKind().GetName()
+		}
+	}
+	return json.Marshal(data)
+}
+
+func mapObjectKindNamesToObjectKinds(names [][]string) ([][]ObjectKind, error) {
+	kinds := make([][]ObjectKind, len(names))
+	for y, row := range names {
+		kinds[y] = make([]ObjectKind, len(row))
+		for x, name := range row {
+			kind, err := FindObjectKind(name)
+			if err != nil {
+				return nil, errors.Wrapf(err, "The object at Y=%d, X=%d is invalid.", y, x)
+			}
+			kinds[y][x] = kind
+		}
+	}
+	return kinds, nil
+}
+
+// Load a field from the format of `Field.MarshalJSON`. It replaces all elements of the field.
+func (field *Field) UnmarshalJSON(content []byte) error {
+	data := &fieldJSON{}
+	err := json.Unmarshal(content, data)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	objectKinds, objectKindsErr := mapObjectKindNamesToObjectKinds(data.Objects)
+	if objectKindsErr != nil {
+		return objectKindsErr
+	}
+	floorObjectKinds, floorObjectKindsErr := mapObjectKindNamesToObjectKinds(data.FloorObjects)
+	if floorObjectKindsErr != nil {
+		return floorObjectKindsErr
+	}
+	var entrance *utils.MatrixPosition
+	if data.Entrance != nil {
+		entrance = &utils.MatrixPosition{Y: data.Entrance.Y, X: data.Entrance.X}
+	}
+	loadedField, createErr := createFieldFromKinds(objectKinds, floorObjectKinds, entrance)
+	if createErr != nil {
+		return createErr
+	}
+	*field = *loadedField
+	return nil
+}
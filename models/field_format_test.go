@@ -0,0 +1,146 @@
+package models
+
+import (
+	"encoding/json"
+	"github.com/kjirou/gRPC-sample-net-game/utils"
+	"strings"
+	"testing"
+)
+
+const testingFieldText = `
+#######
+#@..%.#
+#.#...#
+#...#<#
+#######
+`
+
+func TestParseFieldText_NotTD(t *testing.T) {
+	t.Run("記号から各要素と入口と上り階段を読み込む", func(t *testing.T) {
+		field, err := ParseFieldText(testingFieldText)
+		if err != nil {
+			t.Fatal(err)
+		} else if field.MeasureRowLength() != 5 || field.MeasureColumnLength() != 7 {
+			t.Fatal("大きさが違う")
+		} else if *field.GetEntrancePosition() != (utils.MatrixPosition{Y: 1, X: 1}) {
+			t.Fatal("入口が違う")
+		} else if *field.GetUpstairsPosition() != (utils.MatrixPosition{Y: 3, X: 5}) {
+			t.Fatal("上り階段が違う")
+		}
+		wall, _ := field.At(&utils.MatrixPosition{Y: 2, X: 2})
+		breakableWall, _ := field.At(&utils.MatrixPosition{Y: 1, X: 4})
+		empty, _ := field.At(&utils.MatrixPosition{Y: 2, X: 3})
+		upstairs, _ := field.At(&utils.MatrixPosition{Y: 3, X: 5})
+		if wall.GetObjectKind() != ObjectKindWall || breakableWall.GetObjectKind() != ObjectKindBreakableWall || !empty.IsObjectEmpty() {
+			t.Fatal("物体が違う")
+		} else if upstairs.GetFloorObjectKind() != ObjectKindUpstairs || !upstairs.IsObjectEmpty() {
+			t.Fatal("上り階段が置かれていない")
+		}
+	})
+
+	t.Run("ToText で同じ文字列に戻る", func(t *testing.T) {
+		field, _ := ParseFieldText(testingFieldText)
+		if field.ToText() != strings.TrimLeft(testingFieldText, "\n") {
+			t.Fatal("文字列が異なる")
+		}
+	})
+
+	t.Run("ヒーローは保存しない", func(t *testing.T) {
+		state := CreateState()
+		state.SetWelcomeData()
+		state.AddHero("a")
+		state.AddHero("b")
+		text := state.GetField().ToText()
+		if strings.Count(text, "@") != 1 {
+			t.Fatal("入口だけが @ ではない")
+		} else if !strings.HasPrefix(strings.Split(text, "\n")[1], "#@...") {
+			t.Fatal("ヒーローの位置が空になっていない")
+		}
+	})
+
+	testCases := []struct {
+		title string
+		text string
+		message string
+	}{
+		{title: "未知の記号", text: "#@<x#", message: "line 1, column 4"},
+		{title: "長さが異なる行", text: "#@<#\n##", message: "columns"},
+		{title: "上り階段がない", text: "#@.#", message: "no upstairs"},
+		{title: "複数の上り階段", text: "#@<<#", message: "multiple upstairs"},
+		{title: "入口がない", text: "#..<#", message: "no entrance"},
+		{title: "複数の入口", text: "#@@<#", message: "multiple entrances"},
+	}
+	for _, testCase := range testCases {
+		testCase := testCase
+		t.Run(testCase.title+"はエラーを返す", func(t *testing.T) {
+			_, err := ParseFieldText(testCase.text)
+			if err == nil {
+				t.Fatal("エラーを返さない")
+			} else if !strings.Contains(err.Error(), testCase.message) {
+				t.Fatalf("意図したエラーメッセージではない: %s", err.Error())
+			}
+		})
+	}
+}
+
+func TestField_MarshalJSON_NotTD(t *testing.T) {
+	t.Run("JSON に保存して同じフィールドを読み込める", func(t *testing.T) {
+		field, _ := ParseFieldText(testingFieldText)
+		content, err := json.Marshal(field)
+		if err != nil {
+			t.Fatal(err)
+		}
+		loadedField := &Field{}
+		unmarshalErr := json.Unmarshal(content, loadedField)
+		if unmarshalErr != nil {
+			t.Fatal(unmarshalErr)
+		} else if loadedField.ToText() != field.ToText() {
+			t.Fatal("フィールドが異なる")
+		}
+	})
+
+	t.Run("物体種別の名前で保存する", func(t *testing.T) {
+		field, _ := ParseFieldText("#@<#")
+		content, _ := json.Marshal(field)
+		expected := `{"objects":[["wall","empty","empty","wall"]],` +
+			`"floorObjects":[["empty","empty","upstairs","empty"]],"entrance":{"y":0,"x":1}}`
+		if string(content) != expected {
+			t.Fatalf("形式が違う: %s", string(content))
+		}
+	})
+
+	t.Run("不正な物体種別はエラーを返す", func(t *testing.T) {
+		content := `{"objects":[["hero","empty"]],"floorObjects":[["empty","upstairs"]],"entrance":{"y":0,"x":1}}`
+		err := json.Unmarshal([]byte(content), &Field{})
+		if err == nil {
+			t.Fatal("エラーを返さない")
+		}
+	})
+
+	t.Run("入口が壁のときはエラーを返す", func(t *testing.T) {
+		content := `{"objects":[["wall","empty"]],"floorObjects":[["empty","upstairs"]],"entrance":{"y":0,"x":0}}`
+		err := json.Unmarshal([]byte(content), &Field{})
+		if err == nil {
+			t.Fatal("エラーを返さない")
+		}
+	})
+}
+
+func TestState_ReplaceField_NotTD(t *testing.T) {
+	t.Run("ヒーローを新しいフィールドの入口に置く", func(t *testing.T) {
+		state := CreateState()
+		state.SetWelcomeData()
+		state.AddHero("a")
+		field, _ := ParseFieldText(testingFieldText)
+		err := state.ReplaceField(field)
+		if err != nil {
+			t.Fatal(err)
+		}
+		element, getElementOfHeroErr := state.GetField().GetElementOfHero("a")
+		if getElementOfHeroErr != nil {
+			t.Fatal(getElementOfHeroErr)
+		} else if *element.GetPosition() != *field.GetEntrancePosition() {
+			t.Fatal("入口に置かれていない")
+		}
+	})
+}
@@ -0,0 +1,58 @@
+package models
+
+//
+// Items that a hero carries. They are picked up on floors and used by players at any time.
+//
+
+import (
+	"github.com/pkg/errors"
+)
+
+// The number of items that each hero can carry. Players use them by the number keys from 1.
+const InventoryCapacity = 5
+
+type Inventory struct {
+	// In the order of picking up.
+	itemKinds []ObjectKind
+}
+
+// Return a copy of the items, so that the inventory is not modified by callers.
+func (inventory *Inventory) GetItemKinds() []ObjectKind {
+	itemKinds := make([]ObjectKind, len(inventory.itemKinds))
+	copy(itemKinds, inventory.itemKinds)
+	return itemKinds
+}
+
+func (inventory *Inventory) IsFull() bool {
+	return len(inventory.itemKinds) >= InventoryCapacity
+}
+
+func (inventory *Inventory) Add(itemKind ObjectKind) error {
+	if !itemKind.IsItem() {
+		return errors.Errorf("The %q object kind is not an item.", itemKind.GetName())
+	} else if inventory.IsFull() {
+		return errors.New("The inventory is full.")
+	}
+	inventory.itemKinds = append(inventory.itemKinds, itemKind)
+	return nil
+}
+
+// Remove the item at the `index` and return it. It returns false if the index has no items.
+func (inventory *Inventory) Take(index int) (ObjectKind, bool) {
+	if index < 0 || index >= len(inventory.itemKinds) {
+		return ObjectKindEmpty, false
+	}
+	itemKind := inventory.itemKinds[index]
+	inventory.itemKinds = append(inventory.itemKinds[:index], inventory.itemKinds[index+1:]...)
+	return itemKind, true
+}
+
+func (inventory *Inventory) Clear() {
+	inventory.itemKinds = make([]ObjectKind, 0)
+}
+
+func createInventory() *Inventory {
+	return &Inventory{
+		itemKinds: make([]ObjectKind, 0),
+	}
+}
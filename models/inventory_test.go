@@ -0,0 +1,48 @@
+package models
+
+import (
+	"testing"
+)
+
+func TestInventory_Add_NotTD(t *testing.T) {
+	t.Run("拾った順に持ち、いっぱいになると持てない", func(t *testing.T) {
+		inventory := createInventory()
+		for i := 0; i < InventoryCapacity; i++ {
+			err := inventory.Add(ObjectKindHourglass)
+			if err != nil {
+				t.Fatal(err)
+			}
+		}
+		if !inventory.IsFull() {
+			t.Fatal("いっぱいになっていない")
+		} else if inventory.Add(ObjectKindCompass) == nil {
+			t.Fatal("エラーを返さない")
+		}
+	})
+
+	t.Run("道具ではない物は持てない", func(t *testing.T) {
+		if createInventory().Add(ObjectKindWall) == nil {
+			t.Fatal("エラーを返さない")
+		}
+	})
+}
+
+func TestInventory_Take_NotTD(t *testing.T) {
+	t.Run("指定した位置の道具を取り出し、後ろの道具を詰める", func(t *testing.T) {
+		inventory := createInventory()
+		inventory.Add(ObjectKindHourglass)
+		inventory.Add(ObjectKindCompass)
+		inventory.Add(ObjectKindTeleporter)
+		itemKind, ok := inventory.Take(1)
+		if !ok || itemKind != ObjectKindCompass {
+			t.Fatal("取り出した道具が違う")
+		}
+		itemKinds := inventory.GetItemKinds()
+		if len(itemKinds) != 2 || itemKinds[1] != ObjectKindTeleporter {
+			t.Fatal("詰められていない")
+		}
+		if _, ok := inventory.Take(2); ok {
+			t.Fatal("道具がない位置から取り出せる")
+		}
+	})
+}
@@ -3,15 +3,184 @@ package models
 import (
 	"github.com/kjirou/gRPC-sample-net-game/utils"
 	"github.com/pkg/errors"
+	"math/rand"
+	"sort"
 	"time"
 )
 
+// The default positions of the entrance and the upstairs. `StairsPlacement`s may move them on each floor.
 var HeroPosition = &utils.MatrixPosition{Y: 1, X: 1}
 var UpstairsPosition = &utils.MatrixPosition{Y: 11, X: 19}
 
+// The number of breakable walls that each hero can dig on each floor.
+const DigChargesPerFloor = 3
+
+// The time that an hourglass adds to the time limit.
+const HourglassTimeBonus = 5 * time.Second
+
+// The time while a compass shows the route to the upstairs.
+const CompassDuration = 3 * time.Second
+
+// A hero is the alter ego of a player.
+type Hero struct {
+	// The number of floors where the hero has reached the upstairs first in the current game.
+	clearedFloorCount int
+	// Whether the hero has touched the upstairs on the current floor.
+	hasReachedUpstairs bool
+	playerID string
+	// The number of breakable walls that the hero can still dig on the current floor.
+	remainingDigCharges int
+	// A snapshot of `state.executionTime` until when the route to the upstairs is shown by a compass.
+	compassExpiresAt time.Duration
+	// Items are kept through floors, but not through games.
+	inventory *Inventory
+	// Kinds of keys that the hero has picked up on the current floor.
+	keyKinds []ObjectKind
+}
+
+func (hero *Hero) GetPlayerID() string {
+	return hero.playerID
+}
+
+func (hero *Hero) GetClearedFloorCount() int {
+	return hero.clearedFloorCount
+}
+
+func (hero *Hero) IncrementClearedFloorCount() {
+	hero.clearedFloorCount += 1
+}
+
+func (hero *Hero) HasReachedUpstairs() bool {
+	return hero.hasReachedUpstairs
+}
+
+func (hero *Hero) MarkAsReachedUpstairs() {
+	hero.hasReachedUpstairs = true
+}
+
+func (hero *Hero) GetRemainingDigCharges() int {
+	return hero.remainingDigCharges
+}
+
+func (hero *Hero) GetInventory() *Inventory {
+	return hero.inventory
+}
+
+func (hero *Hero) GetKeyKinds() []ObjectKind {
+	keyKinds := make([]ObjectKind, len(hero.keyKinds))
+	copy(keyKinds, hero.keyKinds)
+	return keyKinds
+}
+
+// A key that the hero already carries is not added twice.
+func (hero *Hero) AddKey(keyKind ObjectKind) error {
+	if !keyKind.IsKey() {
+		return errors.Errorf("The %q object kind is not a key.", keyKind.GetName())
+	}
+	for _, carriedKeyKind := range hero.keyKinds {
+		if carriedKeyKind == keyKind {
+			return nil
+		}
+	}
+	hero.keyKinds = append(hero.keyKinds, keyKind)
+	return nil
+}
+
+// Whether the hero carries a key that opens the door kind.
+func (hero *Hero) CanOpen(doorKind ObjectKind) bool {
+	for _, keyKind := range hero.keyKinds {
+		if keyKind.Opens(doorKind) {
+			return true
+		}
+	}
+	return false
+}
+
+// Use one of the dig charges. It returns false if no charge remains.
+func (hero *Hero) ConsumeDigCharge() bool {
+	if hero.remainingDigCharges <= 0 {
+		return false
+	}
+	hero.remainingDigCharges -= 1
+	return true
+}
+
+// Show the route to the upstairs until the `executionTime` plus the `CompassDuration`.
+func (hero *Hero) ActivateCompass(executionTime time.Duration) {
+	hero.compassExpiresAt = executionTime + CompassDuration
+}
+
+func (hero *Hero) ShowsRoute(executionTime time.Duration) bool {
+	return executionTime < hero.compassExpiresAt
+}
+
+// Prepare for the next floor.
+func (hero *Hero) ResetFloor() {
+	hero.hasReachedUpstairs = false
+	hero.remainingDigCharges = DigChargesPerFloor
+	hero.compassExpiresAt = 0
+	hero.keyKinds = make([]ObjectKind, 0)
+}
+
+func (hero *Hero) Reset() {
+	hero.clearedFloorCount = 0
+	hero.inventory.Clear()
+	hero.ResetFloor()
+}
+
+// The interval between steps of monsters. It is slower than heroes, so they can run away.
+const MonsterStepInterval = 400 * time.Millisecond
+
+// Monsters chase a hero who is within this number of steps. Otherwise, they wander.
+const MonsterChaseRange = 8
+
+// The time that is cut from the time limit when a hero touches a monster.
+const MonsterTouchPenalty = 5 * time.Second
+
+// A monster roams the floor where it has been placed.
+type Monster struct {
+	// Wandering monsters do not go back here unless they are at dead ends.
+	previousPosition *utils.MatrixPosition
+	// Each monster has its own random source, so that the same seed reproduces its wandering.
+	random *rand.Rand
+	waitingTime time.Duration
+}
+
+func (monster *Monster) GetPreviousPosition() (*utils.MatrixPosition, bool) {
+	return monster.previousPosition, monster.previousPosition != nil
+}
+
+func (monster *Monster) SetPreviousPosition(position *utils.MatrixPosition) {
+	monster.previousPosition = position
+}
+
+func (monster *Monster) GetRandom() *rand.Rand {
+	return monster.random
+}
+
+// Let the time pass for the monster. It returns true when the monster can take a step.
+func (monster *Monster) Wait(elapsedTime time.Duration) bool {
+	monster.waitingTime += elapsedTime
+	if monster.waitingTime < MonsterStepInterval {
+		return false
+	}
+	monster.waitingTime -= MonsterStepInterval
+	return true
+}
+
+func CreateMonster(random *rand.Rand) *Monster {
+	return &Monster{
+		random: random,
+	}
+}
+
 type FieldElement struct {
-	floorObjectClass string
-	objectClass string
+	floorObjectKind ObjectKind
+	// It exists only if the `objectKind` is `ObjectKindHero`.
+	hero *Hero
+	// It exists only if the `objectKind` is `ObjectKindMonster`.
+	monster *Monster
+	objectKind ObjectKind
 	position *utils.MatrixPosition
 }
 
@@ -19,28 +188,95 @@ func (fieldElement *FieldElement) GetPosition() *utils.MatrixPosition {
 	return fieldElement.position
 }
 
-func (fieldElement *FieldElement) GetObjectClass() string {
-	return fieldElement.objectClass
+func (fieldElement *FieldElement) GetObjectKind() ObjectKind {
+	return fieldElement.objectKind
+}
+
+func (fieldElement *FieldElement) GetFloorObjectKind() ObjectKind {
+	return fieldElement.floorObjectKind
+}
+
+func (fieldElement *FieldElement) GetHero() (*Hero, bool) {
+	return fieldElement.hero, fieldElement.hero != nil
 }
 
-func (fieldElement *FieldElement) GetFloorObjectClass() string {
-	return fieldElement.floorObjectClass
+func (fieldElement *FieldElement) GetMonster() (*Monster, bool) {
+	return fieldElement.monster, fieldElement.monster != nil
 }
 
 func (fieldElement *FieldElement) IsObjectEmpty() bool {
-	return fieldElement.objectClass == "empty"
+	return fieldElement.objectKind == ObjectKindEmpty
+}
+
+// Whether routes can go through the element, i.e. both the object and the floor object are passable.
+func (fieldElement *FieldElement) IsPassable() bool {
+	return fieldElement.objectKind.IsPassable() && fieldElement.floorObjectKind.IsPassable()
+}
+
+// Whether a hero can step into the element. Only passable elements without objects can be entered.
+func (fieldElement *FieldElement) CanBeEntered() bool {
+	return fieldElement.IsObjectEmpty() && fieldElement.IsPassable()
+}
+
+func (fieldElement *FieldElement) BlocksSight() bool {
+	return fieldElement.objectKind.BlocksSight() || fieldElement.floorObjectKind.BlocksSight()
+}
+
+// Update the object kind.
+// If an actor exists on the element, it is removed.
+func (fieldElement *FieldElement) UpdateObjectKind(kind ObjectKind) {
+	fieldElement.objectKind = kind
+	fieldElement.hero = nil
+	fieldElement.monster = nil
+}
+
+func (fieldElement *FieldElement) placeHero(hero *Hero) {
+	fieldElement.UpdateObjectKind(ObjectKindHero)
+	fieldElement.hero = hero
 }
 
-func (fieldElement *FieldElement) UpdateObjectClass(class string) {
-	fieldElement.objectClass = class
+func (fieldElement *FieldElement) placeMonster(monster *Monster) {
+	fieldElement.UpdateObjectKind(ObjectKindMonster)
+	fieldElement.monster = monster
 }
 
-func (fieldElement *FieldElement) UpdateFloorObjectClass(class string) {
-	fieldElement.floorObjectClass = class
+func (fieldElement *FieldElement) UpdateFloorObjectKind(kind ObjectKind) {
+	fieldElement.floorObjectKind = kind
 }
 
 type Field struct {
+	// Heroes are placed near it.
+	entrancePosition *utils.MatrixPosition
 	matrix [][]*FieldElement
+	upstairsPosition *utils.MatrixPosition
+}
+
+func (field *Field) GetEntrancePosition() *utils.MatrixPosition {
+	return field.entrancePosition
+}
+
+func (field *Field) GetUpstairsPosition() *utils.MatrixPosition {
+	return field.upstairsPosition
+}
+
+// Move the entrance and the upstairs of the floor.
+// It does not move heroes, so call `State.RelocateHeroesToEntrance` after it if needed.
+func (field *Field) RelocateEntranceAndUpstairs(entrance *utils.MatrixPosition, upstairs *utils.MatrixPosition) error {
+	_, entranceElementOk := field.At(entrance)
+	if !entranceElementOk {
+		return errors.New("The entrance's position does not exist on the field.")
+	}
+	upstairsElement, upstairsElementOk := field.At(upstairs)
+	if !upstairsElementOk {
+		return errors.New("The upstairs' position does not exist on the field.")
+	}
+	for _, element := range field.findElementsByFloorObjectKind(ObjectKindUpstairs) {
+		element.UpdateFloorObjectKind(ObjectKindEmpty)
+	}
+	upstairsElement.UpdateFloorObjectKind(ObjectKindUpstairs)
+	field.entrancePosition = entrance
+	field.upstairsPosition = upstairs
+	return nil
 }
 
 func (field *Field) MeasureRowLength() int {
@@ -62,11 +298,11 @@ func (field *Field) At(position *utils.MatrixPosition) (*FieldElement, bool) {
 	return field.matrix[y][x], true
 }
 
-func (field *Field) findElementsByObjectClass(objectClass string) []*FieldElement {
+func (field *Field) findElementsByObjectKind(objectKind ObjectKind) []*FieldElement {
 	elements := make([]*FieldElement, 0)
 	for _, row := range field.matrix {
 		for _, element := range row {
-			if element.objectClass == objectClass {
+			if element.objectKind == objectKind {
 				element_ := element
 				elements = append(elements, element_)
 			}
@@ -75,16 +311,83 @@ func (field *Field) findElementsByObjectClass(objectClass string) []*FieldElemen
 	return elements
 }
 
-func (field *Field) GetElementOfHero() (*FieldElement, error) {
-	elements := field.findElementsByObjectClass("hero")
+func (field *Field) findElementsByFloorObjectKind(floorObjectKind ObjectKind) []*FieldElement {
+	elements := make([]*FieldElement, 0)
+	for _, row := range field.matrix {
+		for _, element := range row {
+			if element.floorObjectKind == floorObjectKind {
+				elements = append(elements, element)
+			}
+		}
+	}
+	return elements
+}
+
+// Return elements of all monsters in the order of rows and columns.
+func (field *Field) GetElementsOfMonsters() []*FieldElement {
+	return field.findElementsByObjectKind(ObjectKindMonster)
+}
+
+func (field *Field) PlaceMonster(position *utils.MatrixPosition, monster *Monster) error {
+	element, elementOk := field.At(position)
+	if !elementOk {
+		return errors.Errorf("The %v position does not exist on the field.", *position)
+	} else if !element.CanBeEntered() {
+		return errors.Errorf("The monster can not be placed at %v.", *position)
+	}
+	element.placeMonster(monster)
+	return nil
+}
+
+func (field *Field) GetElementOfHero(playerID string) (*FieldElement, error) {
+	elements := make([]*FieldElement, 0)
+	for _, element := range field.findElementsByObjectKind(ObjectKindHero) {
+		if element.hero != nil && element.hero.GetPlayerID() == playerID {
+			elements = append(elements, element)
+		}
+	}
 	if len(elements) == 0 {
-		return &FieldElement{}, errors.Errorf("The hero does not exist.")
+		return &FieldElement{}, errors.Errorf("The hero of the %q player does not exist.", playerID)
 	} else if len(elements) > 1 {
-		return &FieldElement{}, errors.Errorf("There are multiple heroes.")
+		return &FieldElement{}, errors.Errorf("There are multiple heroes of the %q player.", playerID)
 	}
 	return elements[0], nil
 }
 
+// Find the element that heroes can enter nearest to the position by walking through passable elements.
+// Heroes do not block the search, so a crowded entrance overflows to the next cells.
+func (field *Field) findEmptyElementNearestTo(position *utils.MatrixPosition) (*FieldElement, bool) {
+	startElement, startElementOk := field.At(position)
+	if !startElementOk {
+		return &FieldElement{}, false
+	}
+	visited := map[*FieldElement]bool{startElement: true}
+	queue := []*FieldElement{startElement}
+	for len(queue) > 0 {
+		element := queue[0]
+		queue = queue[1:]
+		if element.CanBeEntered() {
+			return element, true
+		}
+		y := element.GetPosition().GetY()
+		x := element.GetPosition().GetX()
+		neighbors := []*utils.MatrixPosition{
+			&utils.MatrixPosition{Y: y - 1, X: x},
+			&utils.MatrixPosition{Y: y, X: x + 1},
+			&utils.MatrixPosition{Y: y + 1, X: x},
+			&utils.MatrixPosition{Y: y, X: x - 1},
+		}
+		for _, neighbor := range neighbors {
+			neighborElement, neighborElementOk := field.At(neighbor)
+			if neighborElementOk && !visited[neighborElement] && neighborElement.IsPassable() {
+				visited[neighborElement] = true
+				queue = append(queue, neighborElement)
+			}
+		}
+	}
+	return &FieldElement{}, false
+}
+
 func (field *Field) MoveObject(from *utils.MatrixPosition, to *utils.MatrixPosition) error {
 	fromElement, fromElementOk := field.At(from)
 	if !fromElementOk {
@@ -98,26 +401,64 @@ func (field *Field) MoveObject(from *utils.MatrixPosition, to *utils.MatrixPosit
 	} else if !toElement.IsObjectEmpty() {
 		return errors.New("An object exists at the destination.")
 	}
-	toElement.UpdateObjectClass(fromElement.GetObjectClass())
-	fromElement.UpdateObjectClass("empty")
+	hero, isHero := fromElement.GetHero()
+	monster, isMonster := fromElement.GetMonster()
+	if isHero {
+		toElement.placeHero(hero)
+	} else if isMonster {
+		toElement.placeMonster(monster)
+	} else {
+		toElement.UpdateObjectKind(fromElement.GetObjectKind())
+	}
+	fromElement.UpdateObjectKind(ObjectKindEmpty)
 	return nil
 }
 
-func (field *Field) ResetMaze() error {
+// Return a copy of the field whose elements can be changed independently.
+func (field *Field) Copy() *Field {
+	matrix := make([][]*FieldElement, len(field.matrix))
+	for y, row := range field.matrix {
+		matrix[y] = make([]*FieldElement, len(row))
+		for x, element := range row {
+			copiedElement := *element
+			// Monsters are copied as well, but they share their random sources.
+			if monster, ok := element.GetMonster(); ok {
+				copiedMonster := *monster
+				copiedElement.monster = &copiedMonster
+			}
+			matrix[y][x] = &copiedElement
+		}
+	}
+	return &Field{
+		entrancePosition: field.entrancePosition,
+		matrix: matrix,
+		upstairsPosition: field.upstairsPosition,
+	}
+}
+
+// The same generator and seed always generate the same maze.
+func (field *Field) ResetMaze(generator utils.MazeGenerator, seed int64) error {
 	rowLength := field.MeasureRowLength()
 	columnLength := field.MeasureColumnLength()
-	mazeCells, err := utils.GenerateMaze(rowLength, columnLength)
+	mazeCells, err := generator.Generate(rowLength, columnLength, rand.New(rand.NewSource(seed)))
 	if err != nil {
 		return err
 	}
 	for y, mazeRow := range mazeCells {
 		for x, mazeCell := range mazeRow {
 			element, _ := field.At(&utils.MatrixPosition{Y: y, X: x})
-			switch mazeCell.Content {
-			case utils.MazeCellContentEmpty:
-				element.UpdateObjectClass("empty")
-			case utils.MazeCellContentUnbreakableWall:
-				element.UpdateObjectClass("wall")
+			// Floor objects of the previous floor, e.g. items, are removed. The upstairs is relocated by the caller.
+			if element.GetFloorObjectKind() != ObjectKindUpstairs {
+				element.UpdateFloorObjectKind(ObjectKindEmpty)
+			}
+			switch {
+			case mazeCell.Content == utils.MazeCellContentEmpty:
+				element.UpdateObjectKind(ObjectKindEmpty)
+			// Walls between rooms can be dug by heroes, so that they make shortcuts.
+			case utils.IsWallBetweenRooms(mazeCells, y, x):
+				element.UpdateObjectKind(ObjectKindBreakableWall)
+			default:
+				element.UpdateObjectKind(ObjectKindWall)
 			}
 		}
 	}
@@ -134,34 +475,152 @@ func createField(y int, x int) *Field {
 					Y: rowIndex,
 					X: columnIndex,
 				},
-				objectClass: "empty",
-				floorObjectClass: "empty",
+				objectKind: ObjectKindEmpty,
+				floorObjectKind: ObjectKindEmpty,
 			}
 		}
 		matrix[rowIndex] = row
 	}
 	return &Field{
+		entrancePosition: HeroPosition,
 		matrix: matrix,
+		upstairsPosition: UpstairsPosition,
+	}
+}
+
+type GameMode int
+const (
+	// Anyone's arrival at the upstairs advances the floor of all players.
+	GameModeStandard GameMode = iota
+	// The first hero to the upstairs wins the floor, and players are ranked by the won floors.
+	GameModeRace
+	// The floor advances only after all heroes have touched the upstairs.
+	GameModeCoop
+)
+
+// How to place the entrance and the upstairs on each floor.
+type StairsPlacement int
+const (
+	// The entrance at `HeroPosition` and the upstairs at `UpstairsPosition`.
+	StairsPlacementFixed StairsPlacement = iota
+	// The entrance at `HeroPosition` and the upstairs at the farthest reachable cell from it.
+	StairsPlacementFarthest
+	// The entrance at `HeroPosition` and the upstairs at a random cell that is far enough from it.
+	StairsPlacementDistant
+	// Both at random cells. The upstairs is far enough from the entrance as well as `StairsPlacementDistant`.
+	StairsPlacementRandom
+)
+
+var stairsPlacementNames = map[string]StairsPlacement{
+	"distant": StairsPlacementDistant,
+	"farthest": StairsPlacementFarthest,
+	"fixed": StairsPlacementFixed,
+	"random": StairsPlacementRandom,
+}
+
+func FindStairsPlacement(name string) (StairsPlacement, error) {
+	placement, ok := stairsPlacementNames[name]
+	if !ok {
+		return StairsPlacementFixed, errors.Errorf("The %q stairs placement does not exist.", name)
+	}
+	return placement, nil
+}
+
+// Return names of all placements in alphabetical order.
+func GetStairsPlacementNames() []string {
+	names := make([]string, 0, len(stairsPlacementNames))
+	for name := range stairsPlacementNames {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func (placement StairsPlacement) GetName() string {
+	for name, namedPlacement := range stairsPlacementNames {
+		if namedPlacement == placement {
+			return name
+		}
 	}
+	return ""
+}
+
+// The time limit of a game, or of each level in the campaign.
+const DefaultTimeLimit = 30 * time.Second
+
+// A hand-crafted floor that is used instead of a generated maze.
+type Level struct {
+	Name string
+	// It is a template, so floors use copies of it.
+	Field *Field
+	// If it is 0, the `DefaultTimeLimit` is used.
+	TimeLimit time.Duration
+}
+
+// Options of how floors are generated, e.g. from flags of the client or a request to create a room.
+// The zero value is the default game.
+type GameOptions struct {
+	// Floors use them in turn. If it is empty, the default generator is used.
+	MazeGeneratorNames []string
+	// The fraction of walls between rooms that are broken to make loops, from 0 to 1.
+	LoopDensity float64
+	StairsPlacement StairsPlacement
+	// If it is true, floors get harder as the floor number rises.
+	TargetsDifficulty bool
+	// The number of monsters on each floor.
+	MonsterCount int
+	// The number of items on each floor.
+	ItemCount int
+	// If it is true, later floors have coloured doors and their keys.
+	HasDoors bool
 }
 
 type Game struct {
 	floorNumber int
 	isFinished bool
-	// A snapshot of `state.executionTime` when a game has started.
-	startedAt time.Duration
+	// It is kept through resets.
+	mode GameMode
+	// The seed of the whole game. Each floor's maze is generated from it.
+	seed int64
+	// Names of `utils.MazeGenerator`s that floors use in turn. It is kept through resets.
+	mazeGeneratorNames []string
+	// The fraction of walls between rooms that are broken to make loops. It is kept through resets.
+	loopDensity float64
+	// It is kept through resets.
+	stairsPlacement StairsPlacement
+	// Whether floors are chosen to get harder as the floor number rises. It is kept through resets.
+	targetsDifficulty bool
+	// The number of monsters on each generated floor. It is kept through resets.
+	monsterCount int
+	// The number of items on each generated floor. It is kept through resets.
+	itemCount int
+	// Whether later generated floors have doors and keys. It is kept through resets.
+	hasDoors bool
+	// It is not derived from the execution time, because a game can start at the execution time of 0.
+	isStarted bool
+	// Floors are these levels in order instead of generated mazes, if it is not empty. It is kept through resets.
+	levels []*Level
+	// The floor number at the start of games, e.g. to resume a campaign. It is kept through resets.
+	firstFloorNumber int
+	timeLimit time.Duration
+	// A snapshot of `state.executionTime` when the `timeLimit` has been set.
+	timerStartedAt time.Duration
 }
 
 func (game *Game) Reset() {
 	zeroDuration, _ := time.ParseDuration("0s")
-	game.startedAt = zeroDuration
+	game.isStarted = false
 	game.floorNumber = 1
+	if game.firstFloorNumber > 1 {
+		game.floorNumber = game.firstFloorNumber
+	}
 	game.isFinished = false
+	game.timeLimit = DefaultTimeLimit
+	game.timerStartedAt = zeroDuration
 }
 
 func (game *Game) IsStarted() bool {
-	zeroDuration, _ := time.ParseDuration("0s")
-	return game.startedAt != zeroDuration
+	return game.isStarted
 }
 
 func (game *Game) IsFinished() bool {
@@ -169,17 +628,202 @@ func (game *Game) IsFinished() bool {
 }
 
 func (game *Game) CalculateRemainingTime(executionTime time.Duration) time.Duration {
-	oneGameTime, _ := time.ParseDuration("30s")
 	if game.IsStarted() {
-		playtime := executionTime - game.startedAt
-		remainingTime := oneGameTime - playtime
+		playtime := executionTime - game.timerStartedAt
+		remainingTime := game.timeLimit - playtime
 		if remainingTime < 0 {
 			zeroTime, _ := time.ParseDuration("0s")
 			return zeroTime
 		}
 		return remainingTime
 	}
-	return oneGameTime
+	return game.timeLimit
+}
+
+// Give the `timeLimit` from now, e.g. for each level of the campaign.
+func (game *Game) RestartTimer(executionTime time.Duration, timeLimit time.Duration) {
+	game.timerStartedAt = executionTime
+	game.timeLimit = timeLimit
+}
+
+// Lengthen the time limit by the `delta`, or shorten it by a negative one. It never becomes negative.
+func (game *Game) AdjustTimeLimit(delta time.Duration) {
+	game.timeLimit += delta
+	if game.timeLimit < 0 {
+		game.timeLimit = 0
+	}
+}
+
+// Set the options through the setters, so that invalid options are rejected in the same way.
+func (game *Game) ApplyOptions(options *GameOptions) error {
+	setMazeGeneratorNamesErr := game.SetMazeGeneratorNames(options.MazeGeneratorNames)
+	if setMazeGeneratorNamesErr != nil {
+		return errors.WithStack(setMazeGeneratorNamesErr)
+	}
+	setLoopDensityErr := game.SetLoopDensity(options.LoopDensity)
+	if setLoopDensityErr != nil {
+		return errors.WithStack(setLoopDensityErr)
+	}
+	game.SetStairsPlacement(options.StairsPlacement)
+	game.SetTargetsDifficulty(options.TargetsDifficulty)
+	setMonsterCountErr := game.SetMonsterCount(options.MonsterCount)
+	if setMonsterCountErr != nil {
+		return errors.WithStack(setMonsterCountErr)
+	}
+	setItemCountErr := game.SetItemCount(options.ItemCount)
+	if setItemCountErr != nil {
+		return errors.WithStack(setItemCountErr)
+	}
+	game.SetHasDoors(options.HasDoors)
+	return nil
+}
+
+func (game *Game) GetMode() GameMode {
+	return game.mode
+}
+
+func (game *Game) SetMode(mode GameMode) {
+	game.mode = mode
+}
+
+func (game *Game) GetSeed() int64 {
+	return game.seed
+}
+
+func (game *Game) SetSeed(seed int64) {
+	game.seed = seed
+}
+
+// Calculate the seed of the current floor's maze from the game's seed.
+// It is mixed with a large odd number, so that floors of neighbouring seeds do not share mazes.
+func (game *Game) CalculateMazeSeed() int64 {
+	return game.seed*1000003 + int64(game.floorNumber)
+}
+
+func (game *Game) GetMazeGeneratorNames() []string {
+	return game.mazeGeneratorNames
+}
+
+// Set generators that floors use in turn, e.g. ["prim"] for all floors or ["backtracker", "eller"] alternately.
+// If it is empty, the default generator is used.
+func (game *Game) SetMazeGeneratorNames(names []string) error {
+	for _, name := range names {
+		_, err := utils.FindMazeGenerator(name)
+		if err != nil {
+			return errors.WithStack(err)
+		}
+	}
+	game.mazeGeneratorNames = names
+	return nil
+}
+
+func (game *Game) GetLoopDensity() float64 {
+	return game.loopDensity
+}
+
+// The `loopDensity` must be between 0 and 1. If it is 0, mazes are perfect.
+func (game *Game) SetLoopDensity(loopDensity float64) error {
+	if loopDensity < 0 || loopDensity > 1 {
+		return errors.Errorf("The loop density must be between 0 and 1, but it is %v.", loopDensity)
+	}
+	game.loopDensity = loopDensity
+	return nil
+}
+
+func (game *Game) GetMonsterCount() int {
+	return game.monsterCount
+}
+
+func (game *Game) SetMonsterCount(monsterCount int) error {
+	if monsterCount < 0 {
+		return errors.Errorf("The number of monsters must not be negative, but it is %d.", monsterCount)
+	}
+	game.monsterCount = monsterCount
+	return nil
+}
+
+func (game *Game) GetItemCount() int {
+	return game.itemCount
+}
+
+func (game *Game) SetItemCount(itemCount int) error {
+	if itemCount < 0 {
+		return errors.Errorf("The number of items must not be negative, but it is %d.", itemCount)
+	}
+	game.itemCount = itemCount
+	return nil
+}
+
+func (game *Game) GetStairsPlacement() StairsPlacement {
+	return game.stairsPlacement
+}
+
+func (game *Game) SetStairsPlacement(placement StairsPlacement) {
+	game.stairsPlacement = placement
+}
+
+func (game *Game) TargetsDifficulty() bool {
+	return game.targetsDifficulty
+}
+
+func (game *Game) SetTargetsDifficulty(targetsDifficulty bool) {
+	game.targetsDifficulty = targetsDifficulty
+}
+
+func (game *Game) HasDoors() bool {
+	return game.hasDoors
+}
+
+func (game *Game) SetHasDoors(hasDoors bool) {
+	game.hasDoors = hasDoors
+}
+
+// Return the generator of the current floor.
+func (game *Game) GetMazeGenerator() utils.MazeGenerator {
+	name := utils.DefaultMazeGeneratorName
+	if len(game.mazeGeneratorNames) > 0 {
+		name = game.mazeGeneratorNames[(game.floorNumber-1) % len(game.mazeGeneratorNames)]
+	}
+	// The names have been validated.
+	generator, _ := utils.FindMazeGenerator(name)
+	if game.loopDensity > 0 {
+		return &utils.BraidedMazeGenerator{
+			Base: generator,
+			LoopDensity: game.loopDensity,
+		}
+	}
+	return generator
+}
+
+func (game *Game) GetLevels() []*Level {
+	return game.levels
+}
+
+func (game *Game) SetLevels(levels []*Level) {
+	game.levels = levels
+}
+
+// Return the level of the current floor. The second value is false if floors are generated mazes.
+func (game *Game) GetCurrentLevel() (*Level, bool) {
+	index := game.floorNumber - 1
+	if index < 0 || index >= len(game.levels) {
+		return &Level{}, false
+	}
+	return game.levels[index], true
+}
+
+// Whether the floor is beyond the last level, i.e. the campaign has been completed.
+func (game *Game) HasClearedAllLevels() bool {
+	return len(game.levels) > 0 && game.floorNumber > len(game.levels)
+}
+
+func (game *Game) GetFirstFloorNumber() int {
+	return game.firstFloorNumber
+}
+
+// It takes effect from the next reset.
+func (game *Game) SetFirstFloorNumber(floorNumber int) {
+	game.firstFloorNumber = floorNumber
 }
 
 func (game *Game) GetFloorNumber() int{
@@ -191,7 +835,8 @@ func (game *Game) IncrementFloorNumber() {
 }
 
 func (game *Game) Start(executionTime time.Duration) {
-	game.startedAt = executionTime
+	game.isStarted = true
+	game.timerStartedAt = executionTime
 }
 
 func (game *Game) Finish() {
@@ -204,6 +849,8 @@ type State struct {
 	executionTime time.Duration
 	field *Field
 	game *Game
+	// Heroes in the order of joining.
+	heroes []*Hero
 }
 
 func (state *State) GetExecutionTime() time.Duration {
@@ -218,26 +865,101 @@ func (state *State) GetGame() *Game {
 	return state.game
 }
 
+func (state *State) GetHeroes() []*Hero {
+	return state.heroes
+}
+
 func (state *State) AlterExecutionTime(delta time.Duration) {
 	state.executionTime = state.executionTime + delta
 }
 
-func (state *State) SetWelcomeData() error {
-	field := state.GetField()
+// Return heroes in descending order of cleared floors.
+// Heroes who have cleared the same number of floors are in the order of joining.
+func (state *State) RankHeroes() []*Hero {
+	heroes := make([]*Hero, len(state.heroes))
+	copy(heroes, state.heroes)
+	sort.SliceStable(heroes, func(i, j int) bool {
+		return heroes[i].GetClearedFloorCount() > heroes[j].GetClearedFloorCount()
+	})
+	return heroes
+}
+
+// Place a hero on the empty element that is nearest to the entrance.
+func (state *State) placeHeroAtEntrance(hero *Hero) error {
+	element, elementOk := state.field.findEmptyElementNearestTo(state.field.GetEntrancePosition())
+	if !elementOk {
+		return errors.New("There is no space to place the hero.")
+	}
+	element.placeHero(hero)
+	return nil
+}
 
-	// Place a hero to be the player's alter ego.
-	heroFieldElement, heroFieldElementOk := field.At(HeroPosition)
-	if !heroFieldElementOk {
-		return errors.New("The hero's position does not exist on the field.")
+// Add a hero of the player, and place it near the entrance.
+func (state *State) AddHero(playerID string) (*Hero, error) {
+	for _, hero := range state.heroes {
+		if hero.GetPlayerID() == playerID {
+			return &Hero{}, errors.Errorf("The hero of the %q player already exists.", playerID)
+		}
+	}
+	hero := &Hero{
+		playerID: playerID,
+		remainingDigCharges: DigChargesPerFloor,
+		inventory: createInventory(),
+		keyKinds: make([]ObjectKind, 0),
+	}
+	err := state.placeHeroAtEntrance(hero)
+	if err != nil {
+		return &Hero{}, err
 	}
-	heroFieldElement.UpdateObjectClass("hero")
+	state.heroes = append(state.heroes, hero)
+	return hero, nil
+}
+
+// Remove the hero of the player from both the list and the field.
+func (state *State) RemoveHero(playerID string) error {
+	heroes := make([]*Hero, 0)
+	for _, hero := range state.heroes {
+		if hero.GetPlayerID() != playerID {
+			heroes = append(heroes, hero)
+		}
+	}
+	if len(heroes) == len(state.heroes) {
+		return errors.Errorf("The hero of the %q player does not exist.", playerID)
+	}
+	state.heroes = heroes
+	element, err := state.field.GetElementOfHero(playerID)
+	if err == nil {
+		element.UpdateObjectKind(ObjectKindEmpty)
+	}
+	return nil
+}
+
+// Place all heroes near the entrance in the order of joining.
+// It assumes that heroes have been removed from the field, e.g. by `Field.ResetMaze`.
+func (state *State) RelocateHeroesToEntrance() error {
+	for _, hero := range state.heroes {
+		err := state.placeHeroAtEntrance(hero)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Replace the field with another one, e.g. a hand-crafted floor, and place all heroes near its entrance.
+func (state *State) ReplaceField(field *Field) error {
+	state.field = field
+	return state.RelocateHeroesToEntrance()
+}
+
+func (state *State) SetWelcomeData() error {
+	field := state.GetField()
 
 	// Place an upstairs.
-	upstairsFieldElement, upstairsFieldElementOk := field.At(UpstairsPosition)
-	if !upstairsFieldElementOk {
-		return errors.New("The upstairs' position does not exist on the field.")
+	relocateErr := field.RelocateEntranceAndUpstairs(HeroPosition, UpstairsPosition)
+	if relocateErr != nil {
+		return relocateErr
 	}
-	upstairsFieldElement.UpdateFloorObjectClass("upstairs")
 
 	// Place defalt walls.
 	fieldRowLength := field.MeasureRowLength()
@@ -248,7 +970,7 @@ func (state *State) SetWelcomeData() error {
 			isLeftOrRightEdge := x == 0 || x == fieldColumnLength-1
 			if isTopOrBottomEdge || isLeftOrRightEdge {
 				elem, _ := field.At(&utils.MatrixPosition{Y: y, X: x})
-				elem.UpdateObjectClass("wall")
+				elem.UpdateObjectKind(ObjectKindWall)
 			}
 		}
 	}
@@ -262,6 +984,7 @@ func CreateState() *State {
 		executionTime: executionTime,
 		field: createField(13, 21),
 		game: &Game{},
+		heroes: make([]*Hero, 0),
 	}
 	state.game.Reset()
 	return state
@@ -43,9 +43,22 @@ func TestField_At_NotTD(t *testing.T) {
 }
 
 func TestField_GetElementOfHero_NotTD(t *testing.T) {
+	t.Run("指定したプレイヤーのヒーローの要素を返す", func(t *testing.T) {
+		field := createField(3, 5)
+		field.matrix[0][0].placeHero(&Hero{playerID: "a"})
+		field.matrix[0][1].placeHero(&Hero{playerID: "b"})
+		element, err := field.GetElementOfHero("b")
+		if err != nil {
+			t.Fatal(err)
+		} else if element != field.matrix[0][1] {
+			t.Fatal("別の要素を返す")
+		}
+	})
+
 	t.Run("ヒーローが存在しないときはエラーを返す", func(t *testing.T) {
 		field := createField(3, 5)
-		_, err := field.GetElementOfHero()
+		field.matrix[0][0].placeHero(&Hero{playerID: "a"})
+		_, err := field.GetElementOfHero("b")
 		if err == nil {
 			t.Fatal("エラーを返さない")
 		} else if !strings.Contains(err.Error(), "does not exist") {
@@ -53,11 +66,11 @@ func TestField_GetElementOfHero_NotTD(t *testing.T) {
 		}
 	})
 
-	t.Run("ヒーローが複数存在するときはエラーを返す", func(t *testing.T) {
+	t.Run("同じプレイヤーのヒーローが複数存在するときはエラーを返す", func(t *testing.T) {
 		field := createField(3, 5)
-		field.matrix[0][0].UpdateObjectClass("hero")
-		field.matrix[0][1].UpdateObjectClass("hero")
-		_, err := field.GetElementOfHero()
+		field.matrix[0][0].placeHero(&Hero{playerID: "a"})
+		field.matrix[0][1].placeHero(&Hero{playerID: "a"})
+		_, err := field.GetElementOfHero("a")
 		if err == nil {
 			t.Fatal("エラーを返さない")
 		} else if !strings.Contains(err.Error(), " multiple ") {
@@ -74,17 +87,28 @@ func TestField_MoveObject_NotTD(t *testing.T) {
 	toElement, _ := field.At(toPosition)
 
 	t.Run("始点の物体が空ではなく、終点の物体が空のとき、物体種別が移動する", func(t *testing.T) {
-		fromElement.UpdateObjectClass("wall")
-		toElement.UpdateObjectClass("empty")
+		fromElement.UpdateObjectKind(ObjectKindWall)
+		toElement.UpdateObjectKind(ObjectKindEmpty)
 		field.MoveObject(fromPosition, toPosition)
-		if toElement.GetObjectClass() != "wall" {
+		if toElement.GetObjectKind() != ObjectKindWall {
 			t.Fatal("物体種別が移動していない")
 		}
 	})
 
+	t.Run("ヒーローを移動したとき、プレイヤーも移動する", func(t *testing.T) {
+		fromElement.placeHero(&Hero{playerID: "a"})
+		toElement.UpdateObjectKind(ObjectKindEmpty)
+		field.MoveObject(fromPosition, toPosition)
+		if hero, ok := toElement.GetHero(); !ok || hero.GetPlayerID() != "a" {
+			t.Fatal("プレイヤーが移動していない")
+		} else if _, ok := fromElement.GetHero(); ok {
+			t.Fatal("始点にプレイヤーが残っている")
+		}
+	})
+
 	t.Run("始点の物体が空ではなく、終点の物体が空ではないとき、エラーを返す", func(t *testing.T) {
-		fromElement.UpdateObjectClass("wall")
-		toElement.UpdateObjectClass("wall")
+		fromElement.UpdateObjectKind(ObjectKindWall)
+		toElement.UpdateObjectKind(ObjectKindWall)
 		err := field.MoveObject(fromPosition, toPosition)
 		if err == nil {
 			t.Fatal("エラーを返さない")
@@ -94,7 +118,7 @@ func TestField_MoveObject_NotTD(t *testing.T) {
 	})
 
 	t.Run("始点の物体が空のとき、エラーを返す", func(t *testing.T) {
-		fromElement.UpdateObjectClass("empty")
+		fromElement.UpdateObjectKind(ObjectKindEmpty)
 		err := field.MoveObject(fromPosition, toPosition)
 		if err == nil {
 			t.Fatal("エラーを返さない")
@@ -107,34 +131,339 @@ func TestField_MoveObject_NotTD(t *testing.T) {
 func TestField_ResetMaze_NotTD(t *testing.T) {
 	t.Run("外周1マスは壁になる", func(t *testing.T) {
 		field := createField(7, 7)
-		field.ResetMaze()
+		field.ResetMaze(&utils.ClusteringMazeGenerator{}, 1)
 		for y, row := range field.matrix {
 			for x, element := range row {
 				isTopOrBottomEdge := y == 0 || y == field.MeasureRowLength()-1
 				isLeftOrRightEdge := x == 0 || x == field.MeasureColumnLength()-1
-				if (isTopOrBottomEdge || isLeftOrRightEdge) && element.GetObjectClass() != "wall" {
+				if (isTopOrBottomEdge || isLeftOrRightEdge) && element.GetObjectKind() != ObjectKindWall {
 					t.Fatalf("Y=%d, X=%d が壁ではない", y, x)
 				}
 			}
 		}
 	})
 
+	t.Run("部屋の間の壁だけが壊せる壁になる", func(t *testing.T) {
+		field := createField(7, 7)
+		field.ResetMaze(&utils.ClusteringMazeGenerator{}, 1)
+		breakableWallCount := 0
+		for y, row := range field.matrix {
+			for x, element := range row {
+				if element.GetObjectKind() != ObjectKindBreakableWall {
+					continue
+				}
+				breakableWallCount++
+				isBetweenVerticalRooms := y%2 == 0 && x%2 == 1
+				isBetweenHorizontalRooms := y%2 == 1 && x%2 == 0
+				if !isBetweenVerticalRooms && !isBetweenHorizontalRooms || element.IsPassable() {
+					t.Fatalf("Y=%d, X=%d は壊せる壁ではない", y, x)
+				}
+			}
+		}
+		// 部屋 3*3 の間の壁は 3*2*2 で、そのうち 3*3-1 は通路である。
+		if breakableWallCount != 3*2*2-(3*3-1) {
+			t.Fatal("壊せる壁の数が違う")
+		}
+	})
+
 	t.Run("ヒーローが存在していたとき、ヒーローは削除される", func(t *testing.T) {
 		field := createField(7, 7)
 		element, elementOk := field.At(HeroPosition)
 		if !elementOk {
 			t.Fatal("ヒーローの配置に失敗する")
 		}
-		element.UpdateObjectClass("hero")
-		field.ResetMaze()
+		element.UpdateObjectKind(ObjectKindHero)
+		field.ResetMaze(&utils.ClusteringMazeGenerator{}, 1)
 		for _, row := range field.matrix {
 			for _, element := range row {
-				if element.GetObjectClass() == "hero" {
+				if element.GetObjectKind() == ObjectKindHero {
 					t.Fatal("ヒーローが存在している")
 				}
 			}
 		}
 	})
+
+	t.Run("前の階の道具は削除され、上り階段は残る", func(t *testing.T) {
+		field := createField(7, 7)
+		field.matrix[1][2].UpdateFloorObjectKind(ObjectKindHourglass)
+		field.matrix[5][5].UpdateFloorObjectKind(ObjectKindUpstairs)
+		field.ResetMaze(&utils.ClusteringMazeGenerator{}, 1)
+		if field.matrix[1][2].GetFloorObjectKind() != ObjectKindEmpty {
+			t.Fatal("道具が残っている")
+		} else if field.matrix[5][5].GetFloorObjectKind() != ObjectKindUpstairs {
+			t.Fatal("上り階段が削除されている")
+		}
+	})
+}
+
+func TestField_ResetMaze_Seed_NotTD(t *testing.T) {
+	fieldToText := func(field *Field) string {
+		text := ""
+		for _, row := range field.matrix {
+			for _, element := range row {
+				text += element.GetObjectKind().GetName() + ","
+			}
+		}
+		return text
+	}
+
+	t.Run("同じシードからは同じ迷路になる", func(t *testing.T) {
+		a := createField(13, 21)
+		a.ResetMaze(&utils.ClusteringMazeGenerator{}, 123)
+		b := createField(13, 21)
+		b.ResetMaze(&utils.ClusteringMazeGenerator{}, 123)
+		if fieldToText(a) != fieldToText(b) {
+			t.Fatal("迷路が異なる")
+		}
+	})
+
+	t.Run("ダンジョンの入口と上り階段の位置は空いている", func(t *testing.T) {
+		field := createField(13, 21)
+		err := field.ResetMaze(&utils.DungeonGenerator{}, 123)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, position := range []*utils.MatrixPosition{HeroPosition, UpstairsPosition} {
+			element, _ := field.At(position)
+			if element.GetObjectKind() != ObjectKindEmpty {
+				t.Fatalf("%v が空いていない", position)
+			}
+		}
+	})
+}
+
+func TestField_RelocateEntranceAndUpstairs_NotTD(t *testing.T) {
+	t.Run("上り階段を移動し、入口の位置を記録する", func(t *testing.T) {
+		field := createField(7, 7)
+		field.RelocateEntranceAndUpstairs(HeroPosition, &utils.MatrixPosition{Y: 5, X: 5})
+		entrance := &utils.MatrixPosition{Y: 3, X: 3}
+		upstairs := &utils.MatrixPosition{Y: 1, X: 5}
+		err := field.RelocateEntranceAndUpstairs(entrance, upstairs)
+		if err != nil {
+			t.Fatal(err)
+		} else if field.GetEntrancePosition() != entrance || field.GetUpstairsPosition() != upstairs {
+			t.Fatal("位置を記録していない")
+		}
+		oldElement, _ := field.At(&utils.MatrixPosition{Y: 5, X: 5})
+		newElement, _ := field.At(upstairs)
+		if oldElement.GetFloorObjectKind() != ObjectKindEmpty {
+			t.Fatal("元の上り階段が残っている")
+		} else if newElement.GetFloorObjectKind() != ObjectKindUpstairs {
+			t.Fatal("上り階段が置かれていない")
+		}
+	})
+
+	t.Run("ヒーローは入口の近くに置かれる", func(t *testing.T) {
+		state := CreateState()
+		state.SetWelcomeData()
+		entrance := &utils.MatrixPosition{Y: 5, X: 7}
+		state.GetField().RelocateEntranceAndUpstairs(entrance, UpstairsPosition)
+		state.AddHero("a")
+		element, _ := state.GetField().GetElementOfHero("a")
+		if *element.GetPosition() != *entrance {
+			t.Fatal("入口に置かれていない")
+		}
+	})
+
+	t.Run("フィールド外の位置はエラーを返す", func(t *testing.T) {
+		field := createField(7, 7)
+		if field.RelocateEntranceAndUpstairs(HeroPosition, &utils.MatrixPosition{Y: 7, X: 1}) == nil {
+			t.Fatal("エラーを返さない")
+		}
+	})
+}
+
+func TestGame_CalculateMazeSeed_NotTD(t *testing.T) {
+	t.Run("階ごとに異なるシードを返す", func(t *testing.T) {
+		game := &Game{}
+		game.Reset()
+		game.SetSeed(123)
+		first := game.CalculateMazeSeed()
+		game.IncrementFloorNumber()
+		if game.CalculateMazeSeed() == first {
+			t.Fatal("同じシードを返す")
+		}
+	})
+
+	t.Run("リセットしてもシードは変わらない", func(t *testing.T) {
+		game := &Game{}
+		game.SetSeed(123)
+		game.Reset()
+		if game.GetSeed() != 123 {
+			t.Fatal("シードが変わる")
+		}
+	})
+}
+
+func TestGame_ApplyOptions_NotTD(t *testing.T) {
+	t.Run("各オプションを設定する", func(t *testing.T) {
+		game := &Game{}
+		err := game.ApplyOptions(&GameOptions{
+			MazeGeneratorNames: []string{"prim"},
+			LoopDensity: 0.5,
+			StairsPlacement: StairsPlacementRandom,
+			TargetsDifficulty: true,
+			MonsterCount: 2,
+			ItemCount: 3,
+			HasDoors: true,
+		})
+		if err != nil {
+			t.Fatal(err)
+		} else if game.GetMazeGeneratorNames()[0] != "prim" || game.GetLoopDensity() != 0.5 ||
+			game.GetStairsPlacement() != StairsPlacementRandom || !game.TargetsDifficulty() ||
+			game.GetMonsterCount() != 2 || game.GetItemCount() != 3 || !game.HasDoors() {
+			t.Fatal("設定されていない")
+		}
+	})
+
+	t.Run("不正なオプションはエラーを返す", func(t *testing.T) {
+		game := &Game{}
+		if game.ApplyOptions(&GameOptions{LoopDensity: 2}) == nil {
+			t.Fatal("ループの密度でエラーを返さない")
+		} else if game.ApplyOptions(&GameOptions{ItemCount: -1}) == nil {
+			t.Fatal("道具の数でエラーを返さない")
+		}
+	})
+}
+
+func TestGame_GetMazeGenerator_NotTD(t *testing.T) {
+	t.Run("指定がなければ既定のアルゴリズムを返す", func(t *testing.T) {
+		game := &Game{}
+		game.Reset()
+		if _, ok := game.GetMazeGenerator().(*utils.ClusteringMazeGenerator); !ok {
+			t.Fatal("既定のアルゴリズムではない")
+		}
+	})
+
+	t.Run("階ごとに指定したアルゴリズムを順に返す", func(t *testing.T) {
+		game := &Game{}
+		game.Reset()
+		game.SetMazeGeneratorNames([]string{"prim", "eller"})
+		if _, ok := game.GetMazeGenerator().(*utils.PrimMazeGenerator); !ok {
+			t.Fatal("1階のアルゴリズムが違う")
+		}
+		game.IncrementFloorNumber()
+		if _, ok := game.GetMazeGenerator().(*utils.EllerMazeGenerator); !ok {
+			t.Fatal("2階のアルゴリズムが違う")
+		}
+		game.IncrementFloorNumber()
+		if _, ok := game.GetMazeGenerator().(*utils.PrimMazeGenerator); !ok {
+			t.Fatal("3階のアルゴリズムが違う")
+		}
+	})
+
+	t.Run("ループの密度を指定したときは迷路を編む", func(t *testing.T) {
+		game := &Game{}
+		game.Reset()
+		game.SetLoopDensity(0.2)
+		generator, ok := game.GetMazeGenerator().(*utils.BraidedMazeGenerator)
+		if !ok {
+			t.Fatal("迷路を編まない")
+		} else if generator.LoopDensity != 0.2 {
+			t.Fatal("密度が違う")
+		} else if game.SetLoopDensity(-0.1) == nil {
+			t.Fatal("範囲外の密度を指定できる")
+		}
+	})
+
+	t.Run("存在しないアルゴリズムは指定できない", func(t *testing.T) {
+		game := &Game{}
+		err := game.SetMazeGeneratorNames([]string{"prim", "unknown"})
+		if err == nil {
+			t.Fatal("エラーを返さない")
+		} else if game.GetMazeGeneratorNames() != nil {
+			t.Fatal("指定が反映されている")
+		}
+	})
+}
+
+func TestHero_AddKey_NotTD(t *testing.T) {
+	t.Run("同じ鍵を二度拾っても一つだけ持つ", func(t *testing.T) {
+		hero := &Hero{}
+		hero.AddKey(ObjectKindRedKey)
+		hero.AddKey(ObjectKindRedKey)
+		if len(hero.GetKeyKinds()) != 1 || !hero.CanOpen(ObjectKindRedDoor) {
+			t.Fatal("鍵が一つではない")
+		}
+	})
+
+	t.Run("鍵ではない種別はエラーを返す", func(t *testing.T) {
+		hero := &Hero{}
+		if hero.AddKey(ObjectKindRedDoor) == nil {
+			t.Fatal("エラーを返さない")
+		}
+	})
+}
+
+func TestState_AddHero_NotTD(t *testing.T) {
+	t.Run("入口にヒーローを配置する", func(t *testing.T) {
+		state := CreateState()
+		state.SetWelcomeData()
+		state.AddHero("a")
+		element, _ := state.GetField().GetElementOfHero("a")
+		if element.GetPosition().GetY() != HeroPosition.GetY() || element.GetPosition().GetX() != HeroPosition.GetX() {
+			t.Fatal("入口に配置されていない")
+		}
+	})
+
+	t.Run("入口が塞がっているときは、最寄りの空き要素に配置する", func(t *testing.T) {
+		state := CreateState()
+		state.SetWelcomeData()
+		state.AddHero("a")
+		state.AddHero("b")
+		element, _ := state.GetField().GetElementOfHero("b")
+		distance := element.GetPosition().GetY() - HeroPosition.GetY() + element.GetPosition().GetX() - HeroPosition.GetX()
+		if distance != 1 {
+			t.Fatal("入口の隣に配置されていない")
+		}
+	})
+
+	t.Run("同じプレイヤーのヒーローは追加できない", func(t *testing.T) {
+		state := CreateState()
+		state.SetWelcomeData()
+		state.AddHero("a")
+		_, err := state.AddHero("a")
+		if err == nil {
+			t.Fatal("エラーを返さない")
+		}
+	})
+}
+
+func TestState_RemoveHero_NotTD(t *testing.T) {
+	t.Run("ヒーローを一覧とフィールドから削除する", func(t *testing.T) {
+		state := CreateState()
+		state.SetWelcomeData()
+		state.AddHero("a")
+		state.AddHero("b")
+		err := state.RemoveHero("a")
+		if err != nil {
+			t.Fatal(err)
+		} else if len(state.GetHeroes()) != 1 || state.GetHeroes()[0].GetPlayerID() != "b" {
+			t.Fatal("一覧から削除されていない")
+		} else if _, err := state.GetField().GetElementOfHero("a"); err == nil {
+			t.Fatal("フィールドから削除されていない")
+		}
+	})
+}
+
+func TestState_RankHeroes_NotTD(t *testing.T) {
+	t.Run("踏破した階数の多い順に並べ、同数のときは参加順に並べる", func(t *testing.T) {
+		state := CreateState()
+		state.SetWelcomeData()
+		a, _ := state.AddHero("a")
+		b, _ := state.AddHero("b")
+		c, _ := state.AddHero("c")
+		b.IncrementClearedFloorCount()
+		a.IncrementClearedFloorCount()
+		c.IncrementClearedFloorCount()
+		c.IncrementClearedFloorCount()
+		heroes := state.RankHeroes()
+		if heroes[0] != c || heroes[1] != a || heroes[2] != b {
+			t.Fatal("順位が違う")
+		} else if state.GetHeroes()[0] != a {
+			t.Fatal("元の一覧の順番が変わっている")
+		}
+	})
 }
 
 func TestGame_CalculateRemainingTime_NotTD(t *testing.T) {
@@ -164,11 +493,11 @@ func TestGame_CalculateRemainingTime_NotTD(t *testing.T) {
 func TestGame_Start_NotTD(t *testing.T) {
 	game := &Game{}
 
-	t.Run("It works", func(t *testing.T) {
+	t.Run("実行時間が0でも開始する", func(t *testing.T) {
 		executionTime, _ := time.ParseDuration("0s")
 		game.Start(executionTime)
-		if game.IsStarted() {
-			t.Fatal("開始している")
+		if !game.IsStarted() {
+			t.Fatal("開始していない")
 		}
 		if game.IsFinished() {
 			t.Fatal("終了している")
@@ -0,0 +1,286 @@
+package models
+
+//
+// Kinds of things on field elements. Each kind declares how it behaves, so that a new kind is added here only.
+//
+
+import (
+	"github.com/pkg/errors"
+	"sort"
+)
+
+// Each field element has one object and one floor object. An object stands on a floor object.
+type ObjectLayer int
+const (
+	ObjectLayerObject ObjectLayer = iota
+	ObjectLayerFloor
+)
+
+// Colors are abstracted from termbox, because models do not know terminals.
+type ObjectColor int
+const (
+	ObjectColorWhite ObjectColor = iota
+	ObjectColorBlue
+	ObjectColorCyan
+	ObjectColorGreen
+	ObjectColorMagenta
+	ObjectColorRed
+	ObjectColorYellow
+)
+
+type ObjectKind int
+const (
+	// Nothing. It is the only kind that can be on both layers.
+	ObjectKindEmpty ObjectKind = iota
+	ObjectKindHero
+	ObjectKindWall
+	// A wall between rooms of the maze. Heroes can dig it.
+	ObjectKindBreakableWall
+	ObjectKindUpstairs
+	ObjectKindMonster
+	// Items are picked up when heroes walk onto them.
+	ObjectKindHourglass
+	ObjectKindCompass
+	ObjectKindTeleporter
+	// Doors block everyone but heroes who carry the keys of the same colors.
+	ObjectKindRedDoor
+	ObjectKindBlueDoor
+	ObjectKindRedKey
+	ObjectKindBlueKey
+)
+
+type ObjectKindDefinition struct {
+	// It is used in saved fields, e.g. "wall".
+	Name string
+	Layer ObjectLayer
+	// Whether routes can go through it.
+	IsPassable bool
+	// Actors are heroes and monsters. They move by themselves, so they do not block routes and are not saved.
+	IsActor bool
+	// Items are floor objects that heroes pick up.
+	IsItem bool
+	// A door is opened by a key of the same color. Doors are not passable, so that routes go around them.
+	IsDoor bool
+	// Keys are floor objects that heroes pick up. They are kept until the floor is cleared.
+	IsKey bool
+	BlocksSight bool
+	// The default appearance on the screen. It is also used in the text format of fields.
+	Glyph rune
+	Color ObjectColor
+}
+
+var objectKindDefinitions = map[ObjectKind]*ObjectKindDefinition{
+	ObjectKindEmpty: &ObjectKindDefinition{
+		Name: "empty",
+		Layer: ObjectLayerObject,
+		IsPassable: true,
+		BlocksSight: false,
+		Glyph: '.',
+		Color: ObjectColorWhite,
+	},
+	ObjectKindHero: &ObjectKindDefinition{
+		Name: "hero",
+		Layer: ObjectLayerObject,
+		IsPassable: true,
+		IsActor: true,
+		BlocksSight: false,
+		Glyph: '@',
+		Color: ObjectColorMagenta,
+	},
+	ObjectKindWall: &ObjectKindDefinition{
+		Name: "wall",
+		Layer: ObjectLayerObject,
+		IsPassable: false,
+		BlocksSight: true,
+		Glyph: '#',
+		Color: ObjectColorYellow,
+	},
+	ObjectKindBreakableWall: &ObjectKindDefinition{
+		Name: "breakableWall",
+		Layer: ObjectLayerObject,
+		IsPassable: false,
+		BlocksSight: true,
+		Glyph: '%',
+		Color: ObjectColorYellow,
+	},
+	ObjectKindUpstairs: &ObjectKindDefinition{
+		Name: "upstairs",
+		Layer: ObjectLayerFloor,
+		IsPassable: true,
+		BlocksSight: false,
+		Glyph: '<',
+		Color: ObjectColorGreen,
+	},
+	ObjectKindMonster: &ObjectKindDefinition{
+		Name: "monster",
+		Layer: ObjectLayerObject,
+		IsPassable: true,
+		IsActor: true,
+		BlocksSight: false,
+		Glyph: 'M',
+		Color: ObjectColorRed,
+	},
+	ObjectKindHourglass: &ObjectKindDefinition{
+		Name: "hourglass",
+		Layer: ObjectLayerFloor,
+		IsPassable: true,
+		IsItem: true,
+		BlocksSight: false,
+		Glyph: '+',
+		Color: ObjectColorCyan,
+	},
+	ObjectKindCompass: &ObjectKindDefinition{
+		Name: "compass",
+		Layer: ObjectLayerFloor,
+		IsPassable: true,
+		IsItem: true,
+		BlocksSight: false,
+		Glyph: '*',
+		Color: ObjectColorCyan,
+	},
+	ObjectKindTeleporter: &ObjectKindDefinition{
+		Name: "teleporter",
+		Layer: ObjectLayerFloor,
+		IsPassable: true,
+		IsItem: true,
+		BlocksSight: false,
+		Glyph: '^',
+		Color: ObjectColorMagenta,
+	},
+	ObjectKindRedDoor: &ObjectKindDefinition{
+		Name: "redDoor",
+		Layer: ObjectLayerFloor,
+		IsPassable: false,
+		IsDoor: true,
+		BlocksSight: true,
+		Glyph: 'R',
+		Color: ObjectColorRed,
+	},
+	ObjectKindBlueDoor: &ObjectKindDefinition{
+		Name: "blueDoor",
+		Layer: ObjectLayerFloor,
+		IsPassable: false,
+		IsDoor: true,
+		BlocksSight: true,
+		Glyph: 'B',
+		Color: ObjectColorBlue,
+	},
+	ObjectKindRedKey: &ObjectKindDefinition{
+		Name: "redKey",
+		Layer: ObjectLayerFloor,
+		IsPassable: true,
+		IsKey: true,
+		BlocksSight: false,
+		Glyph: 'r',
+		Color: ObjectColorRed,
+	},
+	ObjectKindBlueKey: &ObjectKindDefinition{
+		Name: "blueKey",
+		Layer: ObjectLayerFloor,
+		IsPassable: true,
+		IsKey: true,
+		BlocksSight: false,
+		Glyph: 'b',
+		Color: ObjectColorBlue,
+	},
+}
+
+func FindObjectKind(name string) (ObjectKind, error) {
+	for kind, definition := range objectKindDefinitions {
+		if definition.Name == name {
+			return kind, nil
+		}
+	}
+	return ObjectKindEmpty, errors.Errorf("The %q object kind does not exist.", name)
+}
+
+// Return all kinds in the order of their values.
+func GetObjectKinds() []ObjectKind {
+	kinds := make([]ObjectKind, 0, len(objectKindDefinitions))
+	for kind := range objectKindDefinitions {
+		kinds = append(kinds, kind)
+	}
+	sort.Slice(kinds, func(i, j int) bool {
+		return kinds[i] < kinds[j]
+	})
+	return kinds
+}
+
+// Return all item kinds in the order of their values.
+func GetItemKinds() []ObjectKind {
+	kinds := make([]ObjectKind, 0)
+	for _, kind := range GetObjectKinds() {
+		if kind.IsItem() {
+			kinds = append(kinds, kind)
+		}
+	}
+	return kinds
+}
+
+// Return all door kinds in the order of their values.
+func GetDoorKinds() []ObjectKind {
+	kinds := make([]ObjectKind, 0)
+	for _, kind := range GetObjectKinds() {
+		if kind.IsDoor() {
+			kinds = append(kinds, kind)
+		}
+	}
+	return kinds
+}
+
+// An unknown kind has the definition of `ObjectKindEmpty`, so that it does not break fields.
+func (kind ObjectKind) GetDefinition() *ObjectKindDefinition {
+	definition, ok := objectKindDefinitions[kind]
+	if !ok {
+		return objectKindDefinitions[ObjectKindEmpty]
+	}
+	return definition
+}
+
+func (kind ObjectKind) GetName() string {
+	return kind.GetDefinition().Name
+}
+
+func (kind ObjectKind) IsPassable() bool {
+	return kind.GetDefinition().IsPassable
+}
+
+func (kind ObjectKind) IsActor() bool {
+	return kind.GetDefinition().IsActor
+}
+
+func (kind ObjectKind) IsItem() bool {
+	return kind.GetDefinition().IsItem
+}
+
+func (kind ObjectKind) IsDoor() bool {
+	return kind.GetDefinition().IsDoor
+}
+
+func (kind ObjectKind) IsKey() bool {
+	return kind.GetDefinition().IsKey
+}
+
+// Whether the kind is a key that opens the door kind.
+func (kind ObjectKind) Opens(doorKind ObjectKind) bool {
+	return kind.IsKey() && doorKind.IsDoor() && kind.GetDefinition().Color == doorKind.GetDefinition().Color
+}
+
+// Return the key kind that opens the door kind. The second value is false if the kind is not a door.
+func (kind ObjectKind) FindKeyKind() (ObjectKind, bool) {
+	for _, keyKind := range GetObjectKinds() {
+		if keyKind.Opens(kind) {
+			return keyKind, true
+		}
+	}
+	return ObjectKindEmpty, false
+}
+
+func (kind ObjectKind) BlocksSight() bool {
+	return kind.GetDefinition().BlocksSight
+}
+
+// Whether the kind can be placed on the layer. `ObjectKindEmpty` can be placed on any layers.
+func (kind ObjectKind) CanBeOn(layer ObjectLayer) bool {
+	return kind == ObjectKindEmpty || kind.GetDefinition().Layer == layer
+}
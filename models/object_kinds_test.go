@@ -0,0 +1,92 @@
+package models
+
+import (
+	"testing"
+)
+
+func TestFindObjectKind_NotTD(t *testing.T) {
+	t.Run("全ての種別を名前から探せる", func(t *testing.T) {
+		for _, kind := range GetObjectKinds() {
+			found, err := FindObjectKind(kind.GetName())
+			if err != nil {
+				t.Fatal(err)
+			} else if found != kind {
+				t.Fatalf("%q が別の種別になる", kind.GetName())
+			}
+		}
+	})
+
+	t.Run("存在しない名前はエラーを返す", func(t *testing.T) {
+		_, err := FindObjectKind("unknown")
+		if err == nil {
+			t.Fatal("エラーを返さない")
+		}
+	})
+}
+
+func TestObjectKind_GetDefinition_NotTD(t *testing.T) {
+	t.Run("保存できる種別の記号は重複せず、入口の記号とも異なる", func(t *testing.T) {
+		kindsByGlyph := make(map[rune]ObjectKind)
+		for _, kind := range GetObjectKinds() {
+			if kind.IsActor() {
+				continue
+			}
+			glyph := kind.GetDefinition().Glyph
+			if glyph == FieldGlyphEntrance {
+				t.Fatalf("%q の記号が入口と同じ", kind.GetName())
+			} else if other, ok := kindsByGlyph[glyph]; ok {
+				t.Fatalf("%q と %q の記号が同じ", kind.GetName(), other.GetName())
+			}
+			kindsByGlyph[glyph] = kind
+		}
+	})
+
+	t.Run("空は両方の層に置け、他の種別は自身の層にだけ置ける", func(t *testing.T) {
+		if !ObjectKindEmpty.CanBeOn(ObjectLayerObject) || !ObjectKindEmpty.CanBeOn(ObjectLayerFloor) {
+			t.Fatal("空を置けない層がある")
+		} else if ObjectKindWall.CanBeOn(ObjectLayerFloor) || ObjectKindUpstairs.CanBeOn(ObjectLayerObject) {
+			t.Fatal("別の層に置ける")
+		}
+	})
+}
+
+func TestFieldElement_CanBeEntered_NotTD(t *testing.T) {
+	t.Run("物体がなく通れる要素にだけ入れる", func(t *testing.T) {
+		element := &FieldElement{}
+		if !element.CanBeEntered() {
+			t.Fatal("空の要素に入れない")
+		}
+		element.UpdateFloorObjectKind(ObjectKindUpstairs)
+		if !element.CanBeEntered() {
+			t.Fatal("上り階段に入れない")
+		}
+		element.UpdateObjectKind(ObjectKindWall)
+		if element.CanBeEntered() || element.IsPassable() || !element.BlocksSight() {
+			t.Fatal("壁に入れる")
+		}
+		element.placeHero(&Hero{})
+		if element.CanBeEntered() || !element.IsPassable() {
+			t.Fatal("ヒーローの扱いが違う")
+		}
+	})
+}
+
+func TestObjectKind_FindKeyKind_NotTD(t *testing.T) {
+	t.Run("各扉には同じ色の鍵がある", func(t *testing.T) {
+		for _, doorKind := range GetDoorKinds() {
+			keyKind, ok := doorKind.FindKeyKind()
+			if !ok || !keyKind.Opens(doorKind) {
+				t.Fatalf("%q の鍵がない", doorKind.GetName())
+			}
+		}
+		if _, ok := ObjectKindWall.FindKeyKind(); ok {
+			t.Fatal("扉ではない種別に鍵がある")
+		}
+	})
+
+	t.Run("色の違う鍵では開かない", func(t *testing.T) {
+		if ObjectKindBlueKey.Opens(ObjectKindRedDoor) {
+			t.Fatal("開いてしまう")
+		}
+	})
+}
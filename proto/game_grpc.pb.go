@@ -0,0 +1,603 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+
+package proto
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+// GameServiceClient is the client API for GameService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type GameServiceClient interface {
+	CreateRoom(ctx context.Context, in *CreateRoomRequest, opts ...grpc.CallOption) (*CreateRoomResponse, error)
+	ListRooms(ctx context.Context, in *ListRoomsRequest, opts ...grpc.CallOption) (*ListRoomsResponse, error)
+	// Add the hero of the player to the room. Other RPCs of the player in the room require it.
+	JoinRoom(ctx context.Context, in *JoinRoomRequest, opts ...grpc.CallOption) (*JoinRoomResponse, error)
+	// Remove the hero of the player from the room. The room is closed when nobody is in it.
+	LeaveRoom(ctx context.Context, in *LeaveRoomRequest, opts ...grpc.CallOption) (*LeaveRoomResponse, error)
+	StartOrRestartGame(ctx context.Context, in *StartOrRestartGameRequest, opts ...grpc.CallOption) (*StartOrRestartGameResponse, error)
+	WalkHero(ctx context.Context, in *WalkHeroRequest, opts ...grpc.CallOption) (*WalkHeroResponse, error)
+	GetScreen(ctx context.Context, in *GetScreenRequest, opts ...grpc.CallOption) (*GetScreenResponse, error)
+	// Push the latest screen every main loop.
+	StreamState(ctx context.Context, in *StreamStateRequest, opts ...grpc.CallOption) (GameService_StreamStateClient, error)
+	// Send inputs of a player continuously, and receive acknowledgements of them.
+	Play(ctx context.Context, opts ...grpc.CallOption) (GameService_PlayClient, error)
+	// Watch a room without a hero. The spectator can switch the player to follow at any time.
+	Spectate(ctx context.Context, opts ...grpc.CallOption) (GameService_SpectateClient, error)
+	// Record the score of the player in the finished game of the room.
+	// The score is calculated by the server, so that clients can not forge it.
+	SubmitScore(ctx context.Context, in *SubmitScoreRequest, opts ...grpc.CallOption) (*SubmitScoreResponse, error)
+	GetTopScores(ctx context.Context, in *GetTopScoresRequest, opts ...grpc.CallOption) (*GetTopScoresResponse, error)
+}
+
+type gameServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewGameServiceClient(cc grpc.ClientConnInterface) GameServiceClient {
+	return &gameServiceClient{cc}
+}
+
+func (c *gameServiceClient) CreateRoom(ctx context.Context, in *CreateRoomRequest, opts ...grpc.CallOption) (*CreateRoomResponse, error) {
+	out := new(CreateRoomResponse)
+	err := c.cc.Invoke(ctx, "/game.GameService/CreateRoom", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gameServiceClient) ListRooms(ctx context.Context, in *ListRoomsRequest, opts ...grpc.CallOption) (*ListRoomsResponse, error) {
+	out := new(ListRoomsResponse)
+	err := c.cc.Invoke(ctx, "/game.GameService/ListRooms", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gameServiceClient) JoinRoom(ctx context.Context, in *JoinRoomRequest, opts ...grpc.CallOption) (*JoinRoomResponse, error) {
+	out := new(JoinRoomResponse)
+	err := c.cc.Invoke(ctx, "/game.GameService/JoinRoom", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gameServiceClient) LeaveRoom(ctx context.Context, in *LeaveRoomRequest, opts ...grpc.CallOption) (*LeaveRoomResponse, error) {
+	out := new(LeaveRoomResponse)
+	err := c.cc.Invoke(ctx, "/game.GameService/LeaveRoom", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gameServiceClient) StartOrRestartGame(ctx context.Context, in *StartOrRestartGameRequest, opts ...grpc.CallOption) (*StartOrRestartGameResponse, error) {
+	out := new(StartOrRestartGameResponse)
+	err := c.cc.Invoke(ctx, "/game.GameService/StartOrRestartGame", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gameServiceClient) WalkHero(ctx context.Context, in *WalkHeroRequest, opts ...grpc.CallOption) (*WalkHeroResponse, error) {
+	out := new(WalkHeroResponse)
+	err := c.cc.Invoke(ctx, "/game.GameService/WalkHero", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gameServiceClient) GetScreen(ctx context.Context, in *GetScreenRequest, opts ...grpc.CallOption) (*GetScreenResponse, error) {
+	out := new(GetScreenResponse)
+	err := c.cc.Invoke(ctx, "/game.GameService/GetScreen", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gameServiceClient) StreamState(ctx context.Context, in *StreamStateRequest, opts ...grpc.CallOption) (GameService_StreamStateClient, error) {
+	stream, err := c.cc.NewStream(ctx, &GameService_ServiceDesc.Streams[0], "/game.GameService/StreamState", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &gameServiceStreamStateClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type GameService_StreamStateClient interface {
+	Recv() (*StreamStateResponse, error)
+	grpc.ClientStream
+}
+
+type gameServiceStreamStateClient struct {
+	grpc.ClientStream
+}
+
+func (x *gameServiceStreamStateClient) Recv() (*StreamStateResponse, error) {
+	m := new(StreamStateResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *gameServiceClient) Play(ctx context.Context, opts ...grpc.CallOption) (GameService_PlayClient, error) {
+	stream, err := c.cc.NewStream(ctx, &GameService_ServiceDesc.Streams[1], "/game.GameService/Play", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &gameServicePlayClient{stream}
+	return x, nil
+}
+
+type GameService_PlayClient interface {
+	Send(*PlayRequest) error
+	Recv() (*PlayResponse, error)
+	grpc.ClientStream
+}
+
+type gameServicePlayClient struct {
+	grpc.ClientStream
+}
+
+func (x *gameServicePlayClient) Send(m *PlayRequest) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *gameServicePlayClient) Recv() (*PlayResponse, error) {
+	m := new(PlayResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *gameServiceClient) Spectate(ctx context.Context, opts ...grpc.CallOption) (GameService_SpectateClient, error) {
+	stream, err := c.cc.NewStream(ctx, &GameService_ServiceDesc.Streams[2], "/game.GameService/Spectate", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &gameServiceSpectateClient{stream}
+	return x, nil
+}
+
+type GameService_SpectateClient interface {
+	Send(*SpectateRequest) error
+	Recv() (*SpectateResponse, error)
+	grpc.ClientStream
+}
+
+type gameServiceSpectateClient struct {
+	grpc.ClientStream
+}
+
+func (x *gameServiceSpectateClient) Send(m *SpectateRequest) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *gameServiceSpectateClient) Recv() (*SpectateResponse, error) {
+	m := new(SpectateResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *gameServiceClient) SubmitScore(ctx context.Context, in *SubmitScoreRequest, opts ...grpc.CallOption) (*SubmitScoreResponse, error) {
+	out := new(SubmitScoreResponse)
+	err := c.cc.Invoke(ctx, "/game.GameService/SubmitScore", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gameServiceClient) GetTopScores(ctx context.Context, in *GetTopScoresRequest, opts ...grpc.CallOption) (*GetTopScoresResponse, error) {
+	out := new(GetTopScoresResponse)
+	err := c.cc.Invoke(ctx, "/game.GameService/GetTopScores", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// GameServiceServer is the server API for GameService service.
+// All implementations must embed UnimplementedGameServiceServer
+// for forward compatibility
+type GameServiceServer interface {
+	CreateRoom(context.Context, *CreateRoomRequest) (*CreateRoomResponse, error)
+	ListRooms(context.Context, *ListRoomsRequest) (*ListRoomsResponse, error)
+	// Add the hero of the player to the room. Other RPCs of the player in the room require it.
+	JoinRoom(context.Context, *JoinRoomRequest) (*JoinRoomResponse, error)
+	// Remove the hero of the player from the room. The room is closed when nobody is in it.
+	LeaveRoom(context.Context, *LeaveRoomRequest) (*LeaveRoomResponse, error)
+	StartOrRestartGame(context.Context, *StartOrRestartGameRequest) (*StartOrRestartGameResponse, error)
+	WalkHero(context.Context, *WalkHeroRequest) (*WalkHeroResponse, error)
+	GetScreen(context.Context, *GetScreenRequest) (*GetScreenResponse, error)
+	// Push the latest screen every main loop.
+	StreamState(*StreamStateRequest, GameService_StreamStateServer) error
+	// Send inputs of a player continuously, and receive acknowledgements of them.
+	Play(GameService_PlayServer) error
+	// Watch a room without a hero. The spectator can switch the player to follow at any time.
+	Spectate(GameService_SpectateServer) error
+	// Record the score of the player in the finished game of the room.
+	// The score is calculated by the server, so that clients can not forge it.
+	SubmitScore(context.Context, *SubmitScoreRequest) (*SubmitScoreResponse, error)
+	GetTopScores(context.Context, *GetTopScoresRequest) (*GetTopScoresResponse, error)
+	mustEmbedUnimplementedGameServiceServer()
+}
+
+// UnimplementedGameServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedGameServiceServer struct {
+}
+
+func (UnimplementedGameServiceServer) CreateRoom(context.Context, *CreateRoomRequest) (*CreateRoomResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateRoom not implemented")
+}
+func (UnimplementedGameServiceServer) ListRooms(context.Context, *ListRoomsRequest) (*ListRoomsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListRooms not implemented")
+}
+func (UnimplementedGameServiceServer) JoinRoom(context.Context, *JoinRoomRequest) (*JoinRoomResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method JoinRoom not implemented")
+}
+func (UnimplementedGameServiceServer) LeaveRoom(context.Context, *LeaveRoomRequest) (*LeaveRoomResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method LeaveRoom not implemented")
+}
+func (UnimplementedGameServiceServer) StartOrRestartGame(context.Context, *StartOrRestartGameRequest) (*StartOrRestartGameResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method StartOrRestartGame not implemented")
+}
+func (UnimplementedGameServiceServer) WalkHero(context.Context, *WalkHeroRequest) (*WalkHeroResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method WalkHero not implemented")
+}
+func (UnimplementedGameServiceServer) GetScreen(context.Context, *GetScreenRequest) (*GetScreenResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetScreen not implemented")
+}
+func (UnimplementedGameServiceServer) StreamState(*StreamStateRequest, GameService_StreamStateServer) error {
+	return status.Errorf(codes.Unimplemented, "method StreamState not implemented")
+}
+func (UnimplementedGameServiceServer) Play(GameService_PlayServer) error {
+	return status.Errorf(codes.Unimplemented, "method Play not implemented")
+}
+func (UnimplementedGameServiceServer) Spectate(GameService_SpectateServer) error {
+	return status.Errorf(codes.Unimplemented, "method Spectate not implemented")
+}
+func (UnimplementedGameServiceServer) SubmitScore(context.Context, *SubmitScoreRequest) (*SubmitScoreResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SubmitScore not implemented")
+}
+func (UnimplementedGameServiceServer) GetTopScores(context.Context, *GetTopScoresRequest) (*GetTopScoresResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTopScores not implemented")
+}
+func (UnimplementedGameServiceServer) mustEmbedUnimplementedGameServiceServer() {}
+
+// UnsafeGameServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to GameServiceServer will
+// result in compilation errors.
+type UnsafeGameServiceServer interface {
+	mustEmbedUnimplementedGameServiceServer()
+}
+
+func RegisterGameServiceServer(s grpc.ServiceRegistrar, srv GameServiceServer) {
+	s.RegisterService(&GameService_ServiceDesc, srv)
+}
+
+func _GameService_CreateRoom_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateRoomRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GameServiceServer).CreateRoom(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/game.GameService/CreateRoom",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GameServiceServer).CreateRoom(ctx, req.(*CreateRoomRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GameService_ListRooms_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListRoomsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GameServiceServer).ListRooms(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/game.GameService/ListRooms",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GameServiceServer).ListRooms(ctx, req.(*ListRoomsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GameService_JoinRoom_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(JoinRoomRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GameServiceServer).JoinRoom(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/game.GameService/JoinRoom",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GameServiceServer).JoinRoom(ctx, req.(*JoinRoomRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GameService_LeaveRoom_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LeaveRoomRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GameServiceServer).LeaveRoom(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/game.GameService/LeaveRoom",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GameServiceServer).LeaveRoom(ctx, req.(*LeaveRoomRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GameService_StartOrRestartGame_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StartOrRestartGameRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GameServiceServer).StartOrRestartGame(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/game.GameService/StartOrRestartGame",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GameServiceServer).StartOrRestartGame(ctx, req.(*StartOrRestartGameRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GameService_WalkHero_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(WalkHeroRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GameServiceServer).WalkHero(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/game.GameService/WalkHero",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GameServiceServer).WalkHero(ctx, req.(*WalkHeroRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GameService_GetScreen_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetScreenRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GameServiceServer).GetScreen(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/game.GameService/GetScreen",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GameServiceServer).GetScreen(ctx, req.(*GetScreenRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GameService_StreamState_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(StreamStateRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(GameServiceServer).StreamState(m, &gameServiceStreamStateServer{stream})
+}
+
+type GameService_StreamStateServer interface {
+	Send(*StreamStateResponse) error
+	grpc.ServerStream
+}
+
+type gameServiceStreamStateServer struct {
+	grpc.ServerStream
+}
+
+func (x *gameServiceStreamStateServer) Send(m *StreamStateResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _GameService_Play_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(GameServiceServer).Play(&gameServicePlayServer{stream})
+}
+
+type GameService_PlayServer interface {
+	Send(*PlayResponse) error
+	Recv() (*PlayRequest, error)
+	grpc.ServerStream
+}
+
+type gameServicePlayServer struct {
+	grpc.ServerStream
+}
+
+func (x *gameServicePlayServer) Send(m *PlayResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *gameServicePlayServer) Recv() (*PlayRequest, error) {
+	m := new(PlayRequest)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func _GameService_Spectate_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(GameServiceServer).Spectate(&gameServiceSpectateServer{stream})
+}
+
+type GameService_SpectateServer interface {
+	Send(*SpectateResponse) error
+	Recv() (*SpectateRequest, error)
+	grpc.ServerStream
+}
+
+type gameServiceSpectateServer struct {
+	grpc.ServerStream
+}
+
+func (x *gameServiceSpectateServer) Send(m *SpectateResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *gameServiceSpectateServer) Recv() (*SpectateRequest, error) {
+	m := new(SpectateRequest)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func _GameService_SubmitScore_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SubmitScoreRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GameServiceServer).SubmitScore(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/game.GameService/SubmitScore",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GameServiceServer).SubmitScore(ctx, req.(*SubmitScoreRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GameService_GetTopScores_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetTopScoresRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GameServiceServer).GetTopScores(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/game.GameService/GetTopScores",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GameServiceServer).GetTopScores(ctx, req.(*GetTopScoresRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// GameService_ServiceDesc is the grpc.ServiceDesc for GameService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var GameService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "game.GameService",
+	HandlerType: (*GameServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "CreateRoom",
+			Handler:    _GameService_CreateRoom_Handler,
+		},
+		{
+			MethodName: "ListRooms",
+			Handler:    _GameService_ListRooms_Handler,
+		},
+		{
+			MethodName: "JoinRoom",
+			Handler:    _GameService_JoinRoom_Handler,
+		},
+		{
+			MethodName: "LeaveRoom",
+			Handler:    _GameService_LeaveRoom_Handler,
+		},
+		{
+			MethodName: "StartOrRestartGame",
+			Handler:    _GameService_StartOrRestartGame_Handler,
+		},
+		{
+			MethodName: "WalkHero",
+			Handler:    _GameService_WalkHero_Handler,
+		},
+		{
+			MethodName: "GetScreen",
+			Handler:    _GameService_GetScreen_Handler,
+		},
+		{
+			MethodName: "SubmitScore",
+			Handler:    _GameService_SubmitScore_Handler,
+		},
+		{
+			MethodName: "GetTopScores",
+			Handler:    _GameService_GetTopScores_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "StreamState",
+			Handler:       _GameService_StreamState_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "Play",
+			Handler:       _GameService_Play_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "Spectate",
+			Handler:       _GameService_Spectate_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
+	},
+	Metadata: "proto/game.proto",
+}
@@ -3,7 +3,9 @@ package reducers
 import(
 	"github.com/pkg/errors"
 	"github.com/kjirou/gRPC-sample-net-game/models"
+	"github.com/kjirou/gRPC-sample-net-game/solver"
 	"github.com/kjirou/gRPC-sample-net-game/utils"
+	"math/rand"
 	"time"
 )
 
@@ -15,30 +17,250 @@ const (
 	FourDirectionLeft
 )
 
+// Generate the current floor of the game, and relocate all heroes to its entrance.
+// If the floor is a level of the campaign, it is used instead of a generated maze.
+func generateFloor(state *models.State) error {
+	game := state.GetGame()
+	if level, ok := game.GetCurrentLevel(); ok {
+		// Each level has its own time limit.
+		timeLimit := level.TimeLimit
+		if timeLimit == 0 {
+			timeLimit = models.DefaultTimeLimit
+		}
+		game.RestartTimer(state.GetExecutionTime(), timeLimit)
+		// Copy the level, so that it can be played again.
+		return errors.WithStack(state.ReplaceField(level.Field.Copy()))
+	}
+
+	// Remove all heroes.
+	err := solver.GenerateFloor(state.GetField(), game)
+	if err != nil {
+		return err
+	}
+	return errors.WithStack(state.RelocateHeroesToEntrance())
+}
+
+// A touched monster disappears, and all players lose `models.MonsterTouchPenalty` from the time limit.
+func touchMonster(state *models.State, monsterElement *models.FieldElement) {
+	state.GetGame().AdjustTimeLimit(-models.MonsterTouchPenalty)
+	monsterElement.UpdateObjectKind(models.ObjectKindEmpty)
+}
+
+// Warp the hero at the `position` to a random empty element other than the upstairs.
+// The destination is decided by the floor and the time, so that the same play warps to the same place.
+func teleportHero(state *models.State, position *utils.MatrixPosition) error {
+	field := state.GetField()
+	upstairs := field.GetUpstairsPosition()
+	candidates := make([]*utils.MatrixPosition, 0)
+	for y := 0; y < field.MeasureRowLength(); y++ {
+		for x := 0; x < field.MeasureColumnLength(); x++ {
+			candidate := &utils.MatrixPosition{Y: y, X: x}
+			element, _ := field.At(candidate)
+			if element.CanBeEntered() && *candidate != *upstairs {
+				candidates = append(candidates, candidate)
+			}
+		}
+	}
+	if len(candidates) == 0 {
+		return nil
+	}
+	random := rand.New(rand.NewSource(state.GetGame().CalculateMazeSeed() + int64(state.GetExecutionTime())))
+	return errors.WithStack(field.MoveObject(position, candidates[random.Intn(len(candidates))]))
+}
+
+// The hero picks up the item on the element into the inventory.
+// If the inventory is full, the item is left on the floor.
+func pickUpItem(heroElement *models.FieldElement) error {
+	itemKind := heroElement.GetFloorObjectKind()
+	if !itemKind.IsItem() {
+		return nil
+	}
+	hero, ok := heroElement.GetHero()
+	if !ok {
+		return errors.Errorf("The %v position does not have a hero.", heroElement.GetPosition())
+	} else if hero.GetInventory().IsFull() {
+		return nil
+	}
+	heroElement.UpdateFloorObjectKind(models.ObjectKindEmpty)
+	return errors.WithStack(hero.GetInventory().Add(itemKind))
+}
+
+// Whether the hero can go through the door on the element. Doors stay open, so the key is not used up.
+func canOpenDoor(hero *models.Hero, element *models.FieldElement) bool {
+	return element.IsObjectEmpty() && hero.CanOpen(element.GetFloorObjectKind())
+}
+
+// The hero picks up the key on the element. Keys are not in the inventory, because they are used by walking.
+// The key is left on the floor, so that each hero has to get their own and no one is shut out by the door.
+func pickUpKey(heroElement *models.FieldElement) error {
+	keyKind := heroElement.GetFloorObjectKind()
+	if !keyKind.IsKey() {
+		return nil
+	}
+	hero, ok := heroElement.GetHero()
+	if !ok {
+		return errors.Errorf("The %v position does not have a hero.", heroElement.GetPosition())
+	}
+	return errors.WithStack(hero.AddKey(keyKind))
+}
+
+// The item takes effect on the hero at the element.
+func applyItem(state *models.State, heroElement *models.FieldElement, itemKind models.ObjectKind) error {
+	hero, ok := heroElement.GetHero()
+	if !ok {
+		return errors.Errorf("The %v position does not have a hero.", heroElement.GetPosition())
+	}
+	switch itemKind {
+	case models.ObjectKindHourglass:
+		state.GetGame().AdjustTimeLimit(models.HourglassTimeBonus)
+	case models.ObjectKindCompass:
+		hero.ActivateCompass(state.GetExecutionTime())
+	case models.ObjectKindTeleporter:
+		return teleportHero(state, heroElement.GetPosition())
+	}
+	return nil
+}
+
+// Return the next step to the nearest hero within `models.MonsterChaseRange` steps.
+func findMonsterChasingStep(
+	field *models.Field, position *utils.MatrixPosition, heroPositions []*utils.MatrixPosition) (*utils.MatrixPosition, bool) {
+	var shortestPath solver.Path
+	for _, heroPosition := range heroPositions {
+		path, ok := solver.FindPathWithAStar(field, position, heroPosition)
+		if ok && path.GetDistance() <= models.MonsterChaseRange &&
+			(shortestPath == nil || path.GetDistance() < shortestPath.GetDistance()) {
+			shortestPath = path
+		}
+	}
+	if shortestPath == nil || shortestPath.GetDistance() == 0 {
+		return nil, false
+	}
+	return shortestPath[1], true
+}
+
+// Return a random step to an adjacent empty element.
+// The monster does not go back to the previous position unless it is at a dead end, so that it roams along corridors.
+func findMonsterWanderingStep(
+	field *models.Field, monster *models.Monster, position *utils.MatrixPosition) (*utils.MatrixPosition, bool) {
+	previousPosition, hasPreviousPosition := monster.GetPreviousPosition()
+	candidates := make([]*utils.MatrixPosition, 0, 4)
+	var backPosition *utils.MatrixPosition
+	directions := []FourDirection{FourDirectionUp, FourDirectionRight, FourDirectionDown, FourDirectionLeft}
+	for _, direction := range directions {
+		nextPosition := calculateNextPosition(position, direction)
+		element, ok := field.At(nextPosition)
+		if !ok || !element.CanBeEntered() {
+			continue
+		}
+		if hasPreviousPosition && *nextPosition == *previousPosition {
+			backPosition = nextPosition
+			continue
+		}
+		candidates = append(candidates, nextPosition)
+	}
+	if len(candidates) == 0 {
+		return backPosition, backPosition != nil
+	}
+	return candidates[monster.GetRandom().Intn(len(candidates))], true
+}
+
+// Each monster takes a step at every `models.MonsterStepInterval`.
+// It chases the nearest hero by the shortest path, or wanders if no heroes are within the range.
+// A monster that steps into a hero touches the hero.
+func moveMonsters(state *models.State, elapsedTime time.Duration) error {
+	field := state.GetField()
+	heroPositions := make([]*utils.MatrixPosition, 0, len(state.GetHeroes()))
+	for _, hero := range state.GetHeroes() {
+		element, err := field.GetElementOfHero(hero.GetPlayerID())
+		if err != nil {
+			return errors.WithStack(err)
+		}
+		heroPositions = append(heroPositions, element.GetPosition())
+	}
+
+	for _, element := range field.GetElementsOfMonsters() {
+		monster, ok := element.GetMonster()
+		if !ok || !monster.Wait(elapsedTime) {
+			continue
+		}
+		position := element.GetPosition()
+		nextPosition, nextPositionOk := findMonsterChasingStep(field, position, heroPositions)
+		if !nextPositionOk {
+			nextPosition, nextPositionOk = findMonsterWanderingStep(field, monster, position)
+		}
+		if !nextPositionOk {
+			continue
+		}
+		nextElement, _ := field.At(nextPosition)
+		if _, isHero := nextElement.GetHero(); isHero {
+			touchMonster(state, element)
+		} else if nextElement.CanBeEntered() {
+			err := field.MoveObject(position, nextPosition)
+			if err != nil {
+				return errors.WithStack(err)
+			}
+			monster.SetPreviousPosition(position)
+		}
+	}
+	return nil
+}
+
 func proceedMainLoopFrame(state *models.State, elapsedTime time.Duration) (*models.State, error) {
 	game := state.GetGame()
 	field := state.GetField()
 
 	// In the game.
 	if game.IsStarted() && !game.IsFinished() {
-		// The hero climbs up the stairs.
-		heroFieldElement, getElementOfHeroErr := field.GetElementOfHero()
-		if getElementOfHeroErr != nil {
-			return state, errors.WithStack(getElementOfHeroErr)
-		}
-		if (heroFieldElement.GetFloorObjectClass() == "upstairs") {
-			// Generate a new maze.
-			// Remove the hero.
-			err := field.ResetMaze()
-			if err != nil {
-				return state, errors.WithStack(err)
+		moveMonstersErr := moveMonsters(state, elapsedTime)
+		if moveMonstersErr != nil {
+			return state, moveMonstersErr
+		}
+
+		// Heroes touch the stairs.
+		// Only one hero can stand on the upstairs, so the hero is the winner of the floor.
+		var winner *models.Hero
+		for _, hero := range state.GetHeroes() {
+			heroFieldElement, getElementOfHeroErr := field.GetElementOfHero(hero.GetPlayerID())
+			if getElementOfHeroErr != nil {
+				return state, errors.WithStack(getElementOfHeroErr)
 			}
+			if heroFieldElement.GetFloorObjectKind() == models.ObjectKindUpstairs {
+				hero.MarkAsReachedUpstairs()
+				winner = hero
+			}
+		}
 
-			// Relocate the hero to the entrance.
-			replacedHeroFieldElement, _ := field.At(models.HeroPosition)
-			replacedHeroFieldElement.UpdateObjectClass("hero")
+		// Climb up the stairs.
+		// In the co-op mode, all heroes must have touched the upstairs. Otherwise, any one of them is enough.
+		canClimbUp := winner != nil
+		if game.GetMode() == models.GameModeCoop {
+			canClimbUp = len(state.GetHeroes()) > 0
+			for _, hero := range state.GetHeroes() {
+				if !hero.HasReachedUpstairs() {
+					canClimbUp = false
+				}
+			}
+		}
+		if canClimbUp {
+			if winner != nil {
+				winner.IncrementClearedFloorCount()
+			}
+			for _, hero := range state.GetHeroes() {
+				hero.ResetFloor()
+			}
 
 			game.IncrementFloorNumber()
+
+			if game.HasClearedAllLevels() {
+				// The campaign has been completed.
+				game.Finish()
+			} else {
+				// Generate a new maze of the next floor, and relocate all heroes to its entrance.
+				err := generateFloor(state)
+				if err != nil {
+					return state, err
+				}
+			}
 		}
 
 		// Time over of this game.
@@ -57,41 +279,32 @@ func AdvanceOnlyTime(state models.State, elapsedTime time.Duration) (*models.Sta
 	return proceedMainLoopFrame(&state, elapsedTime)
 }
 
-func StartOrRestartGame(state models.State, elapsedTime time.Duration) (*models.State, error) {
+// All mazes of the game are generated from the `seed`, so the same seed reproduces the same game.
+func StartOrRestartGame(state models.State, elapsedTime time.Duration, seed int64) (*models.State, error) {
 	game := state.GetGame()
-	field := state.GetField()
 
-	// Generate a new maze.
-	// Remove the hero.
-	err := field.ResetMaze()
+	game.Reset()
+	game.SetSeed(seed)
+
+	// Generate a new maze, and replace all heroes.
+	err := generateFloor(&state)
 	if err != nil {
-		return &state, errors.WithStack(err)
+		return &state, err
 	}
 
-	// Replace the hero.
-	heroFieldElement, _ := field.At(models.HeroPosition)
-	heroFieldElement.UpdateObjectClass("hero")
-
 	// Start the new game.
-	game.Reset()
+	for _, hero := range state.GetHeroes() {
+		hero.Reset()
+	}
 	game.Start(state.GetExecutionTime())
 
 	return proceedMainLoopFrame(&state, elapsedTime)
 }
 
-func WalkHero(state models.State, elapsedTime time.Duration, direction FourDirection) (*models.State, error) {
-	game := state.GetGame()
-	if game.IsFinished() {
-		return &state, nil
-	}
-
-	field := state.GetField()
-	element, getElementOfHeroErr := field.GetElementOfHero()
-	if getElementOfHeroErr != nil {
-		return &state, errors.WithStack(getElementOfHeroErr)
-	}
-	nextY := element.GetPosition().GetY()
-	nextX := element.GetPosition().GetX()
+// Return the adjacent position in the direction. It may be out of the field.
+func calculateNextPosition(position *utils.MatrixPosition, direction FourDirection) *utils.MatrixPosition {
+	nextY := position.GetY()
+	nextX := position.GetX()
 	switch direction {
 	case FourDirectionUp:
 		nextY -= 1
@@ -102,19 +315,88 @@ func WalkHero(state models.State, elapsedTime time.Duration, direction FourDirec
 	case FourDirectionLeft:
 		nextX -= 1
 	}
-	position := element.GetPosition()
-	nextPosition := &utils.MatrixPosition{
+	return &utils.MatrixPosition{
 		Y: nextY,
 		X: nextX,
 	}
+}
+
+// Move the hero of the player.
+// Heroes can not pass through each other, so a hero blocked by another hero stays there.
+func WalkHero(
+	state models.State, elapsedTime time.Duration, playerID string, direction FourDirection) (*models.State, error) {
+	game := state.GetGame()
+	if game.IsFinished() {
+		return &state, nil
+	}
+
+	field := state.GetField()
+	element, getElementOfHeroErr := field.GetElementOfHero(playerID)
+	if getElementOfHeroErr != nil {
+		return &state, errors.WithStack(getElementOfHeroErr)
+	}
+	hero, _ := element.GetHero()
+	position := element.GetPosition()
+	nextPosition := calculateNextPosition(position, direction)
 	if nextPosition.Validate(field.MeasureRowLength(), field.MeasureColumnLength()) {
 		element, elementOk := field.At(nextPosition)
 		if !elementOk {
 			return &state, errors.Errorf("The %v position does not exist on the field.", nextPosition)
-		} else if element.IsObjectEmpty() {
+		} else if element.CanBeEntered() || canOpenDoor(hero, element) {
 			err := field.MoveObject(position, nextPosition)
-			return &state, errors.WithStack(err)
+			if err != nil {
+				return &state, errors.WithStack(err)
+			}
+			pickUpKeyErr := pickUpKey(element)
+			if pickUpKeyErr != nil {
+				return &state, pickUpKeyErr
+			}
+			return &state, pickUpItem(element)
+		} else if element.GetObjectKind() == models.ObjectKindMonster {
+			touchMonster(&state, element)
+			return &state, nil
 		}
 	}
 	return proceedMainLoopFrame(&state, elapsedTime)
 }
+
+// Use the item at the `index` of the inventory of the player's hero. If the index has no items, nothing happens.
+func UseItem(state models.State, elapsedTime time.Duration, playerID string, index int) (*models.State, error) {
+	game := state.GetGame()
+	if game.IsFinished() {
+		return &state, nil
+	}
+
+	element, getElementOfHeroErr := state.GetField().GetElementOfHero(playerID)
+	if getElementOfHeroErr != nil {
+		return &state, errors.WithStack(getElementOfHeroErr)
+	}
+	hero, _ := element.GetHero()
+	if itemKind, ok := hero.GetInventory().Take(index); ok {
+		return &state, applyItem(&state, element, itemKind)
+	}
+	return proceedMainLoopFrame(&state, elapsedTime)
+}
+
+// Dig the breakable wall next to the hero of the player, and it becomes a passage.
+// It uses one of the hero's dig charges of the floor. Without charges or breakable walls, nothing happens.
+func DigWall(
+	state models.State, elapsedTime time.Duration, playerID string, direction FourDirection) (*models.State, error) {
+	game := state.GetGame()
+	if game.IsFinished() {
+		return &state, nil
+	}
+
+	field := state.GetField()
+	element, getElementOfHeroErr := field.GetElementOfHero(playerID)
+	if getElementOfHeroErr != nil {
+		return &state, errors.WithStack(getElementOfHeroErr)
+	}
+	hero, _ := element.GetHero()
+	targetElement, targetElementOk := field.At(calculateNextPosition(element.GetPosition(), direction))
+	if targetElementOk && targetElement.GetObjectKind() == models.ObjectKindBreakableWall && hero.ConsumeDigCharge() {
+		targetElement.UpdateObjectKind(models.ObjectKindEmpty)
+		return &state, nil
+	}
+	return proceedMainLoopFrame(&state, elapsedTime)
+}
@@ -0,0 +1,615 @@
+package reducers
+
+import (
+	"github.com/kjirou/gRPC-sample-net-game/models"
+	"github.com/kjirou/gRPC-sample-net-game/utils"
+	"math/rand"
+	"testing"
+	"time"
+)
+
+// Create a state where the game has started with the heroes of the players.
+func createStartedState(t *testing.T, mode models.GameMode, playerIDs ...string) *models.State {
+	state := models.CreateState()
+	state.SetWelcomeData()
+	state.GetGame().SetMode(mode)
+	for _, playerID := range playerIDs {
+		_, err := state.AddHero(playerID)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	newState, err := StartOrRestartGame(*state, 0, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return newState
+}
+
+func moveHeroToUpstairs(t *testing.T, state *models.State, playerID string) {
+	element, err := state.GetField().GetElementOfHero(playerID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	moveErr := state.GetField().MoveObject(element.GetPosition(), state.GetField().GetUpstairsPosition())
+	if moveErr != nil {
+		t.Fatal(moveErr)
+	}
+}
+
+func TestAdvanceOnlyTime_NotTD(t *testing.T) {
+	t.Run("レースモードでは、上り階段に到達したヒーローだけが階を踏破する", func(t *testing.T) {
+		state := createStartedState(t, models.GameModeRace, "a", "b")
+		moveHeroToUpstairs(t, state, "b")
+		newState, err := AdvanceOnlyTime(*state, 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		heroes := newState.GetHeroes()
+		if heroes[0].GetClearedFloorCount() != 0 {
+			t.Fatal("a が踏破している")
+		} else if heroes[1].GetClearedFloorCount() != 1 {
+			t.Fatal("b が踏破していない")
+		}
+	})
+
+	t.Run("上り階段に到達すると、全員が入口へ戻る", func(t *testing.T) {
+		state := createStartedState(t, models.GameModeRace, "a", "b")
+		moveHeroToUpstairs(t, state, "b")
+		newState, _ := AdvanceOnlyTime(*state, 0)
+		element, _ := newState.GetField().GetElementOfHero("a")
+		if element.GetPosition().GetY() != models.HeroPosition.GetY() || element.GetPosition().GetX() != models.HeroPosition.GetX() {
+			t.Fatal("a が入口にいない")
+		} else if newState.GetGame().GetFloorNumber() != 2 {
+			t.Fatal("階数が進んでいない")
+		}
+	})
+}
+
+func TestAdvanceOnlyTime_Coop_NotTD(t *testing.T) {
+	t.Run("協力モードでは、全員が上り階段に触れるまで階が進まない", func(t *testing.T) {
+		state := createStartedState(t, models.GameModeCoop, "a", "b")
+		moveHeroToUpstairs(t, state, "a")
+		newState, _ := AdvanceOnlyTime(*state, 0)
+		if newState.GetGame().GetFloorNumber() != 1 {
+			t.Fatal("階が進んでいる")
+		} else if !newState.GetHeroes()[0].HasReachedUpstairs() {
+			t.Fatal("a が上り階段に触れたことになっていない")
+		}
+	})
+
+	t.Run("協力モードでは、上り階段から離れたヒーローも触れたことになる", func(t *testing.T) {
+		state := createStartedState(t, models.GameModeCoop, "a", "b")
+		aElement, _ := state.GetField().GetElementOfHero("a")
+		aEntrancePosition := aElement.GetPosition()
+		moveHeroToUpstairs(t, state, "a")
+		state, _ = AdvanceOnlyTime(*state, 0)
+		// Swap "a" on the upstairs for "b".
+		state.GetField().MoveObject(models.UpstairsPosition, aEntrancePosition)
+		moveHeroToUpstairs(t, state, "b")
+		newState, _ := AdvanceOnlyTime(*state, 0)
+		if newState.GetGame().GetFloorNumber() != 2 {
+			t.Fatal("階が進んでいない")
+		}
+		for _, hero := range newState.GetHeroes() {
+			if hero.HasReachedUpstairs() {
+				t.Fatal("次の階で触れたことになっている")
+			}
+		}
+	})
+}
+
+func TestStartOrRestartGame_NotTD(t *testing.T) {
+	t.Run("踏破した階数をリセットする", func(t *testing.T) {
+		state := createStartedState(t, models.GameModeRace, "a")
+		state.GetHeroes()[0].IncrementClearedFloorCount()
+		newState, _ := StartOrRestartGame(*state, 0, 1)
+		if newState.GetHeroes()[0].GetClearedFloorCount() != 0 {
+			t.Fatal("リセットされていない")
+		}
+	})
+
+	t.Run("魔物の数を指定すると各階に置く", func(t *testing.T) {
+		state := createStartedState(t, models.GameModeStandard, "a")
+		state.GetGame().SetMonsterCount(2)
+		newState, _ := StartOrRestartGame(*state, 0, 1)
+		if len(newState.GetField().GetElementsOfMonsters()) != 2 {
+			t.Fatal("魔物の数が違う")
+		}
+	})
+
+	t.Run("同じシードからは同じ迷路で開始する", func(t *testing.T) {
+		fieldToText := func(state *models.State) string {
+			text := ""
+			field := state.GetField()
+			for y := 0; y < field.MeasureRowLength(); y++ {
+				for x := 0; x < field.MeasureColumnLength(); x++ {
+					element, _ := field.At(&utils.MatrixPosition{Y: y, X: x})
+					text += element.GetObjectKind().GetName() + ","
+				}
+			}
+			return text
+		}
+		a, _ := StartOrRestartGame(*createStartedState(t, models.GameModeStandard, "a"), 0, 123)
+		b, _ := StartOrRestartGame(*createStartedState(t, models.GameModeStandard, "a"), 0, 123)
+		if a.GetGame().GetSeed() != 123 {
+			t.Fatal("シードを保持していない")
+		} else if fieldToText(a) != fieldToText(b) {
+			t.Fatal("迷路が異なる")
+		}
+	})
+
+	t.Run("階段の配置方法に従って各階の入口と上り階段を置き、ヒーローを入口に置く", func(t *testing.T) {
+		state := models.CreateState()
+		state.SetWelcomeData()
+		state.GetGame().SetStairsPlacement(models.StairsPlacementRandom)
+		state.AddHero("a")
+		assertFloor := func(state *models.State) {
+			field := state.GetField()
+			element, _ := field.GetElementOfHero("a")
+			if *element.GetPosition() != *field.GetEntrancePosition() {
+				t.Fatal("ヒーローが入口にいない")
+			}
+			upstairsCount := 0
+			for y := 0; y < field.MeasureRowLength(); y++ {
+				for x := 0; x < field.MeasureColumnLength(); x++ {
+					element, _ := field.At(&utils.MatrixPosition{Y: y, X: x})
+					if element.GetFloorObjectKind() == models.ObjectKindUpstairs {
+						upstairsCount++
+						if y != field.GetUpstairsPosition().GetY() || x != field.GetUpstairsPosition().GetX() {
+							t.Fatal("上り階段の位置が違う")
+						}
+					}
+				}
+			}
+			if upstairsCount != 1 {
+				t.Fatal("上り階段が1つではない")
+			}
+		}
+		state, _ = StartOrRestartGame(*state, 0, 123)
+		assertFloor(state)
+		firstEntrance := *state.GetField().GetEntrancePosition()
+		moveHeroToUpstairs(t, state, "a")
+		state, _ = AdvanceOnlyTime(*state, time.Millisecond)
+		if state.GetGame().GetFloorNumber() != 2 {
+			t.Fatal("上の階に移動していない")
+		}
+		assertFloor(state)
+		if *state.GetField().GetEntrancePosition() == firstEntrance {
+			t.Fatal("入口が移動していない")
+		}
+	})
+}
+
+func TestStartOrRestartGame_Campaign_NotTD(t *testing.T) {
+	createLevel := func(text string, timeLimit time.Duration) *models.Level {
+		field, err := models.ParseFieldText(text)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return &models.Level{Field: field, TimeLimit: timeLimit}
+	}
+	createCampaignState := func() *models.State {
+		state := models.CreateState()
+		state.SetWelcomeData()
+		state.GetGame().SetLevels([]*models.Level{
+			createLevel("#####\n#@.<#\n#####", 0),
+			createLevel("######\n#@..<#\n######", 10*time.Second),
+		})
+		state.AddHero("a")
+		return state
+	}
+
+	t.Run("迷路の代わりにレベルを順に使い、最後のレベルをクリアすると終了する", func(t *testing.T) {
+		state, err := StartOrRestartGame(*createCampaignState(), 0, 1)
+		if err != nil {
+			t.Fatal(err)
+		} else if state.GetField().MeasureColumnLength() != 5 {
+			t.Fatal("1つ目のレベルではない")
+		} else if state.GetGame().CalculateRemainingTime(state.GetExecutionTime()) != models.DefaultTimeLimit {
+			t.Fatal("既定の制限時間ではない")
+		}
+
+		moveHeroToUpstairs(t, state, "a")
+		state, _ = AdvanceOnlyTime(*state, time.Second)
+		if state.GetField().MeasureColumnLength() != 6 {
+			t.Fatal("2つ目のレベルではない")
+		} else if state.GetGame().CalculateRemainingTime(state.GetExecutionTime()) != 9*time.Second {
+			t.Fatal("レベルの制限時間から数えていない")
+		}
+		element, _ := state.GetField().GetElementOfHero("a")
+		if *element.GetPosition() != *state.GetField().GetEntrancePosition() {
+			t.Fatal("ヒーローが入口にいない")
+		}
+
+		moveHeroToUpstairs(t, state, "a")
+		state, _ = AdvanceOnlyTime(*state, time.Millisecond)
+		if !state.GetGame().IsFinished() || !state.GetGame().HasClearedAllLevels() {
+			t.Fatal("キャンペーンを完了していない")
+		}
+	})
+
+	t.Run("レベルは複製して使うので、もう一度遊べる", func(t *testing.T) {
+		original := createCampaignState()
+		levelField := original.GetGame().GetLevels()[0].Field
+		state, _ := StartOrRestartGame(*original, 0, 1)
+		if state.GetField() == levelField {
+			t.Fatal("レベルのフィールドをそのまま使っている")
+		} else if element, _ := levelField.At(models.HeroPosition); !element.IsObjectEmpty() {
+			t.Fatal("レベルのフィールドにヒーローが置かれている")
+		}
+	})
+
+	t.Run("開始する階を指定できる", func(t *testing.T) {
+		state := createCampaignState()
+		state.GetGame().SetFirstFloorNumber(2)
+		state, _ = StartOrRestartGame(*state, 0, 1)
+		if state.GetGame().GetFloorNumber() != 2 || state.GetField().MeasureColumnLength() != 6 {
+			t.Fatal("2つ目のレベルから始まっていない")
+		}
+	})
+}
+
+func TestDigWall_NotTD(t *testing.T) {
+	// 入口の右隣を壊せる壁にする。
+	placeBreakableWall := func(t *testing.T, state *models.State) *models.FieldElement {
+		element, _ := state.GetField().GetElementOfHero("a")
+		wall, wallOk := state.GetField().At(&utils.MatrixPosition{
+			Y: element.GetPosition().GetY(),
+			X: element.GetPosition().GetX() + 1,
+		})
+		if !wallOk {
+			t.Fatal("壁を置けない")
+		}
+		wall.UpdateObjectKind(models.ObjectKindBreakableWall)
+		return wall
+	}
+
+	t.Run("隣の壊せる壁を掘ると通路になり、回数を1つ使う", func(t *testing.T) {
+		state := createStartedState(t, models.GameModeStandard, "a")
+		wall := placeBreakableWall(t, state)
+		state, err := DigWall(*state, 0, "a", FourDirectionRight)
+		if err != nil {
+			t.Fatal(err)
+		} else if !wall.IsObjectEmpty() {
+			t.Fatal("通路になっていない")
+		} else if state.GetHeroes()[0].GetRemainingDigCharges() != models.DigChargesPerFloor-1 {
+			t.Fatal("回数を使っていない")
+		}
+		state, _ = WalkHero(*state, 0, "a", FourDirectionRight)
+		if element, _ := state.GetField().GetElementOfHero("a"); element != wall {
+			t.Fatal("掘った位置へ移動できない")
+		}
+	})
+
+	t.Run("壊せない壁は掘れず、回数も使わない", func(t *testing.T) {
+		state := createStartedState(t, models.GameModeStandard, "a")
+		state, _ = DigWall(*state, 0, "a", FourDirectionUp)
+		element, _ := state.GetField().GetElementOfHero("a")
+		wall, _ := state.GetField().At(&utils.MatrixPosition{Y: element.GetPosition().GetY() - 1, X: element.GetPosition().GetX()})
+		if wall.GetObjectKind() != models.ObjectKindWall {
+			t.Fatal("壁が掘られている")
+		} else if state.GetHeroes()[0].GetRemainingDigCharges() != models.DigChargesPerFloor {
+			t.Fatal("回数を使っている")
+		}
+	})
+
+	t.Run("回数を使い切ると掘れず、次の階で回復する", func(t *testing.T) {
+		state := createStartedState(t, models.GameModeStandard, "a")
+		for i := 0; i < models.DigChargesPerFloor; i++ {
+			placeBreakableWall(t, state)
+			state, _ = DigWall(*state, 0, "a", FourDirectionRight)
+		}
+		wall := placeBreakableWall(t, state)
+		state, _ = DigWall(*state, 0, "a", FourDirectionRight)
+		if wall.GetObjectKind() != models.ObjectKindBreakableWall {
+			t.Fatal("回数を使い切っても掘れる")
+		}
+
+		moveHeroToUpstairs(t, state, "a")
+		state, _ = AdvanceOnlyTime(*state, time.Millisecond)
+		if state.GetGame().GetFloorNumber() != 2 {
+			t.Fatal("次の階へ進んでいない")
+		} else if state.GetHeroes()[0].GetRemainingDigCharges() != models.DigChargesPerFloor {
+			t.Fatal("回数が回復していない")
+		}
+	})
+}
+
+func TestMoveMonsters_NotTD(t *testing.T) {
+	// 一本道のフィールドで開始し、指定した位置に魔物を置く。
+	createCorridorState := func(t *testing.T, monsterX int) *models.State {
+		state := createStartedState(t, models.GameModeStandard, "a")
+		field, err := models.ParseFieldText("################\n#@............<#\n################")
+		if err != nil {
+			t.Fatal(err)
+		}
+		state.ReplaceField(field)
+		placeErr := field.PlaceMonster(
+			&utils.MatrixPosition{Y: 1, X: monsterX}, models.CreateMonster(rand.New(rand.NewSource(1))))
+		if placeErr != nil {
+			t.Fatal(placeErr)
+		}
+		return state
+	}
+	findMonsterX := func(t *testing.T, state *models.State) int {
+		elements := state.GetField().GetElementsOfMonsters()
+		if len(elements) != 1 {
+			t.Fatal("魔物がいない")
+		}
+		return elements[0].GetPosition().GetX()
+	}
+
+	t.Run("一定時間ごとに1歩ずつ、範囲内のヒーローへ最短経路で近づく", func(t *testing.T) {
+		state := createCorridorState(t, 5)
+		state, _ = AdvanceOnlyTime(*state, models.MonsterStepInterval/2)
+		if findMonsterX(t, state) != 5 {
+			t.Fatal("間隔より早く動いている")
+		}
+		state, _ = AdvanceOnlyTime(*state, models.MonsterStepInterval/2)
+		if findMonsterX(t, state) != 4 {
+			t.Fatal("ヒーローへ近づいていない")
+		}
+	})
+
+	t.Run("ヒーローに触れると消え、制限時間が減る", func(t *testing.T) {
+		state := createCorridorState(t, 3)
+		remainingTime := state.GetGame().CalculateRemainingTime(state.GetExecutionTime())
+		state, _ = AdvanceOnlyTime(*state, models.MonsterStepInterval)
+		state, _ = AdvanceOnlyTime(*state, models.MonsterStepInterval)
+		if len(state.GetField().GetElementsOfMonsters()) != 0 {
+			t.Fatal("魔物が消えていない")
+		}
+		expected := remainingTime - models.MonsterStepInterval*2 - models.MonsterTouchPenalty
+		if state.GetGame().CalculateRemainingTime(state.GetExecutionTime()) != expected {
+			t.Fatal("制限時間が減っていない")
+		}
+	})
+
+	t.Run("ヒーローが魔物へ歩いても触れる", func(t *testing.T) {
+		state := createCorridorState(t, 2)
+		state, _ = WalkHero(*state, 0, "a", FourDirectionRight)
+		if len(state.GetField().GetElementsOfMonsters()) != 0 {
+			t.Fatal("魔物が消えていない")
+		} else if element, _ := state.GetField().GetElementOfHero("a"); element.GetPosition().GetX() != 1 {
+			t.Fatal("ヒーローが移動している")
+		}
+	})
+
+	t.Run("範囲外のヒーローは追わずにうろつき、行き止まりまで引き返さない", func(t *testing.T) {
+		state := createCorridorState(t, 12)
+		state, _ = AdvanceOnlyTime(*state, models.MonsterStepInterval)
+		x := findMonsterX(t, state)
+		if x != 11 && x != 13 {
+			t.Fatal("隣へ動いていない")
+		}
+		direction := x - 12
+		for step := 0; step < 3; step++ {
+			previousX := findMonsterX(t, state)
+			state, _ = AdvanceOnlyTime(*state, models.MonsterStepInterval)
+			x = findMonsterX(t, state)
+			isAtDeadEnd := previousX == 14
+			if !isAtDeadEnd && x-previousX != direction {
+				t.Fatal("引き返している")
+			} else if isAtDeadEnd {
+				break
+			}
+		}
+	})
+}
+
+func TestWalkHero_Item_NotTD(t *testing.T) {
+	t.Run("道具の上へ歩くと拾って持ち物に入れる", func(t *testing.T) {
+		state := createStartedState(t, models.GameModeStandard, "a")
+		field, _ := models.ParseFieldText("########\n#@+...<#\n########")
+		state.ReplaceField(field)
+		state, _ = WalkHero(*state, 0, "a", FourDirectionRight)
+		element, _ := state.GetField().At(&utils.MatrixPosition{Y: 1, X: 2})
+		if _, ok := element.GetHero(); !ok {
+			t.Fatal("道具の上へ歩けない")
+		} else if element.GetFloorObjectKind() != models.ObjectKindEmpty {
+			t.Fatal("道具が消えていない")
+		}
+		itemKinds := state.GetHeroes()[0].GetInventory().GetItemKinds()
+		if len(itemKinds) != 1 || itemKinds[0] != models.ObjectKindHourglass {
+			t.Fatal("持ち物に入っていない")
+		}
+	})
+
+	t.Run("持ち物がいっぱいのときは拾わない", func(t *testing.T) {
+		state := createStartedState(t, models.GameModeStandard, "a")
+		field, _ := models.ParseFieldText("########\n#@+...<#\n########")
+		state.ReplaceField(field)
+		inventory := state.GetHeroes()[0].GetInventory()
+		for !inventory.IsFull() {
+			inventory.Add(models.ObjectKindCompass)
+		}
+		state, _ = WalkHero(*state, 0, "a", FourDirectionRight)
+		element, _ := state.GetField().At(&utils.MatrixPosition{Y: 1, X: 2})
+		if element.GetFloorObjectKind() != models.ObjectKindHourglass {
+			t.Fatal("道具が消えている")
+		}
+	})
+}
+
+func TestWalkHero_Door_NotTD(t *testing.T) {
+	// 入口の右隣に鍵、その右に赤い扉がある。
+	createDoorState := func(t *testing.T) *models.State {
+		state := createStartedState(t, models.GameModeStandard, "a")
+		field, err := models.ParseFieldText("#######\n#@rR.<#\n#######")
+		if err != nil {
+			t.Fatal(err)
+		}
+		state.ReplaceField(field)
+		return state
+	}
+	findHeroX := func(state *models.State) int {
+		element, _ := state.GetField().GetElementOfHero("a")
+		return element.GetPosition().GetX()
+	}
+
+	t.Run("鍵を持たずに扉へは入れない", func(t *testing.T) {
+		state := createDoorState(t)
+		element, _ := state.GetField().At(&utils.MatrixPosition{Y: 1, X: 2})
+		element.UpdateFloorObjectKind(models.ObjectKindEmpty)
+		state, _ = WalkHero(*state, 0, "a", FourDirectionRight)
+		state, _ = WalkHero(*state, 0, "a", FourDirectionRight)
+		if findHeroX(state) != 2 {
+			t.Fatal("扉へ入っている")
+		}
+	})
+
+	t.Run("鍵を拾うと同じ色の扉を通れる", func(t *testing.T) {
+		state := createDoorState(t)
+		state, _ = WalkHero(*state, 0, "a", FourDirectionRight)
+		keyKinds := state.GetHeroes()[0].GetKeyKinds()
+		if len(keyKinds) != 1 || keyKinds[0] != models.ObjectKindRedKey {
+			t.Fatal("鍵を拾っていない")
+		}
+		element, _ := state.GetField().At(&utils.MatrixPosition{Y: 1, X: 2})
+		if element.GetFloorObjectKind() != models.ObjectKindRedKey {
+			t.Fatal("鍵が床に残っていない")
+		}
+		state, _ = WalkHero(*state, 0, "a", FourDirectionRight)
+		state, _ = WalkHero(*state, 0, "a", FourDirectionRight)
+		if findHeroX(state) != 4 {
+			t.Fatal("扉を通れない")
+		}
+		door, _ := state.GetField().At(&utils.MatrixPosition{Y: 1, X: 3})
+		if door.GetFloorObjectKind() != models.ObjectKindRedDoor {
+			t.Fatal("扉が消えている")
+		}
+	})
+
+	t.Run("レースモードでは鍵をヒーローごとに拾い、他のヒーローは自分で拾わなければ扉を通れない", func(t *testing.T) {
+		// b が脇道の奥の鍵を拾って扉を通り、その後に a も鍵を拾って扉を通る。
+		state := createStartedState(t, models.GameModeRace, "a", "b")
+		field, err := models.ParseFieldText("#########\n#@..R..<#\n##.######\n##r######\n#########")
+		if err != nil {
+			t.Fatal(err)
+		}
+		state.ReplaceField(field)
+		b, _ := state.GetField().GetElementOfHero("b")
+		if b.GetPosition().GetY() != 1 || b.GetPosition().GetX() != 2 {
+			t.Fatal("b が入口の隣にいない")
+		}
+		walk := func(playerID string, directions ...FourDirection) {
+			for _, direction := range directions {
+				state, _ = WalkHero(*state, 0, playerID, direction)
+			}
+		}
+		walk("b", FourDirectionDown, FourDirectionDown, FourDirectionUp, FourDirectionUp,
+			FourDirectionRight, FourDirectionRight, FourDirectionRight)
+		b, _ = state.GetField().GetElementOfHero("b")
+		if b.GetPosition().GetX() != 5 {
+			t.Fatal("b が扉を通れない")
+		}
+		for _, hero := range state.GetHeroes() {
+			if hero.GetPlayerID() == "a" && hero.CanOpen(models.ObjectKindRedDoor) {
+				t.Fatal("拾っていない a が鍵を持っている")
+			}
+		}
+		c, _ := state.AddHero("c")
+		if c.CanOpen(models.ObjectKindRedDoor) {
+			t.Fatal("後から参加したヒーローが鍵を持っている")
+		}
+		state.RemoveHero("c")
+
+		walk("a", FourDirectionRight, FourDirectionDown, FourDirectionDown, FourDirectionUp, FourDirectionUp,
+			FourDirectionRight, FourDirectionRight)
+		a, _ := state.GetField().GetElementOfHero("a")
+		if a.GetPosition().GetX() != 4 {
+			t.Fatal("a が自分で拾った鍵で扉を通れない")
+		}
+	})
+
+	t.Run("色の違う鍵では通れない", func(t *testing.T) {
+		state := createDoorState(t)
+		element, _ := state.GetField().At(&utils.MatrixPosition{Y: 1, X: 2})
+		element.UpdateFloorObjectKind(models.ObjectKindBlueKey)
+		state, _ = WalkHero(*state, 0, "a", FourDirectionRight)
+		state, _ = WalkHero(*state, 0, "a", FourDirectionRight)
+		if findHeroX(state) != 2 {
+			t.Fatal("扉へ入っている")
+		}
+	})
+}
+
+func TestUseItem_NotTD(t *testing.T) {
+	// 道具を1つ持たせて開始する。
+	createItemState := func(t *testing.T, itemKind models.ObjectKind) *models.State {
+		state := createStartedState(t, models.GameModeStandard, "a")
+		field, err := models.ParseFieldText("########\n#@....<#\n########")
+		if err != nil {
+			t.Fatal(err)
+		}
+		state.ReplaceField(field)
+		state.GetHeroes()[0].GetInventory().Add(itemKind)
+		return state
+	}
+
+	t.Run("砂時計を使うと持ち物から消え、制限時間が増える", func(t *testing.T) {
+		state := createItemState(t, models.ObjectKindHourglass)
+		remainingTime := state.GetGame().CalculateRemainingTime(state.GetExecutionTime())
+		state, _ = UseItem(*state, 0, "a", 0)
+		if len(state.GetHeroes()[0].GetInventory().GetItemKinds()) != 0 {
+			t.Fatal("持ち物から消えていない")
+		} else if state.GetGame().CalculateRemainingTime(state.GetExecutionTime()) != remainingTime+models.HourglassTimeBonus {
+			t.Fatal("制限時間が増えていない")
+		}
+	})
+
+	t.Run("羅針盤を使うと、しばらく経路を示す", func(t *testing.T) {
+		state := createItemState(t, models.ObjectKindCompass)
+		state, _ = UseItem(*state, 0, "a", 0)
+		hero := state.GetHeroes()[0]
+		if !hero.ShowsRoute(state.GetExecutionTime()) {
+			t.Fatal("経路を示していない")
+		}
+		state, _ = AdvanceOnlyTime(*state, models.CompassDuration)
+		if hero.ShowsRoute(state.GetExecutionTime()) {
+			t.Fatal("経路を示し続けている")
+		}
+	})
+
+	t.Run("転送装置を使うと、上り階段以外のどこかへ移る", func(t *testing.T) {
+		state := createItemState(t, models.ObjectKindTeleporter)
+		state, _ = UseItem(*state, 0, "a", 0)
+		element, _ := state.GetField().GetElementOfHero("a")
+		position := element.GetPosition()
+		if position.GetX() == 1 || position.GetX() == 6 {
+			t.Fatal("転送されていない")
+		}
+	})
+
+	t.Run("道具がない番号では何も起きない", func(t *testing.T) {
+		state := createItemState(t, models.ObjectKindHourglass)
+		remainingTime := state.GetGame().CalculateRemainingTime(state.GetExecutionTime())
+		state, err := UseItem(*state, 0, "a", 1)
+		if err != nil {
+			t.Fatal(err)
+		} else if len(state.GetHeroes()[0].GetInventory().GetItemKinds()) != 1 {
+			t.Fatal("持ち物が変わっている")
+		} else if state.GetGame().CalculateRemainingTime(state.GetExecutionTime()) != remainingTime {
+			t.Fatal("制限時間が変わっている")
+		}
+	})
+
+	t.Run("持ち物は次の階へ持ち越し、ゲームを再開すると空になる", func(t *testing.T) {
+		state := createStartedState(t, models.GameModeStandard, "a")
+		state.GetHeroes()[0].GetInventory().Add(models.ObjectKindHourglass)
+		moveHeroToUpstairs(t, state, "a")
+		state, _ = AdvanceOnlyTime(*state, 0)
+		if state.GetGame().GetFloorNumber() != 2 {
+			t.Fatal("次の階へ進んでいない")
+		} else if len(state.GetHeroes()[0].GetInventory().GetItemKinds()) != 1 {
+			t.Fatal("持ち越していない")
+		}
+		state, err := StartOrRestartGame(*state, 0, 1)
+		if err != nil {
+			t.Fatalf("%+v", err)
+		} else if len(state.GetHeroes()[0].GetInventory().GetItemKinds()) != 0 {
+			t.Fatal("空になっていない")
+		}
+	})
+}
@@ -0,0 +1,42 @@
+//go:build server
+// +build server
+
+package main
+
+import (
+	"flag"
+	"fmt"
+	"github.com/kjirou/gRPC-sample-net-game/leaderboard"
+	"github.com/kjirou/gRPC-sample-net-game/server"
+	pb "github.com/kjirou/gRPC-sample-net-game/proto"
+	"google.golang.org/grpc"
+	"math/rand"
+	"net"
+	"time"
+)
+
+func main() {
+	var address string
+	var scoresPath string
+	flag.StringVar(&address, "address", ":50051", "The address to listen on.")
+	flag.StringVar(&scoresPath, "scores", leaderboard.DefaultFileName, "The file path of the leaderboard.")
+	flag.Parse()
+
+	rand.Seed(time.Now().UnixNano())
+
+	gameServer := server.CreateGameServer(server.MainLoopInterval, leaderboard.CreateFileStore(scoresPath))
+	defer gameServer.Close()
+
+	listener, listenErr := net.Listen("tcp", address)
+	if listenErr != nil {
+		panic(listenErr)
+	}
+	grpcServer := grpc.NewServer()
+	pb.RegisterGameServiceServer(grpcServer, gameServer)
+
+	fmt.Printf("Listening on %s\n", listener.Addr().String())
+	serveErr := grpcServer.Serve(listener)
+	if serveErr != nil {
+		panic(serveErr)
+	}
+}
@@ -0,0 +1,362 @@
+package server
+
+import (
+	"github.com/kjirou/gRPC-sample-net-game/controller"
+	"github.com/kjirou/gRPC-sample-net-game/models"
+	pb "github.com/kjirou/gRPC-sample-net-game/proto"
+	"github.com/kjirou/gRPC-sample-net-game/reducers"
+	"github.com/pkg/errors"
+	"math/rand"
+	"sync"
+	"time"
+)
+
+// A room is a match. It owns a state and runs its own main loop.
+type Room struct {
+	id string
+	name string
+	// The seed of every game. If it is 0, each game has a random seed.
+	seed int64
+	// It guards the `state` and the `screenPropsSubscribers` that are touched by both the main loop and RPCs.
+	mutex sync.Mutex
+	state *models.State
+	// Channels of StreamState RPCs and the player IDs who see the screens.
+	// Each of them receives the latest screen every main loop.
+	screenPropsSubscribers map[chan *pb.ScreenProps]string
+	// Channels of Spectate RPCs. Each of them is notified every main loop.
+	frameSubscribers map[chan struct{}]bool
+	// Players who have submitted scores of the current game.
+	scoreSubmittedPlayerIDs map[string]bool
+	// It is closed when the room is closed.
+	closed chan struct{}
+	closeOnce sync.Once
+}
+
+func (room *Room) GetID() string {
+	return room.id
+}
+
+func (room *Room) GetName() string {
+	return room.name
+}
+
+func (room *Room) GetState() *models.State {
+	room.mutex.Lock()
+	defer room.mutex.Unlock()
+	return room.state
+}
+
+// The `mutex` must be locked by the caller.
+func (room *Room) getPlayerIDs() []string {
+	playerIDs := make([]string, 0)
+	for _, hero := range room.state.GetHeroes() {
+		playerIDs = append(playerIDs, hero.GetPlayerID())
+	}
+	return playerIDs
+}
+
+// All fields are read from one state under one lock, so that they are not mixed from two states.
+func (room *Room) toMessage() *pb.Room {
+	room.mutex.Lock()
+	defer room.mutex.Unlock()
+	game := room.state.GetGame()
+	return &pb.Room{
+		RoomId: room.id,
+		Name: room.name,
+		PlayerIds: room.getPlayerIDs(),
+		Mode: mapGameModeToGameModeMessage(game.GetMode()),
+		Seed: room.seed,
+		MazeGeneratorNames: game.GetMazeGeneratorNames(),
+		LoopDensity: game.GetLoopDensity(),
+		StairsPlacement: game.GetStairsPlacement().GetName(),
+		TargetsDifficulty: game.TargetsDifficulty(),
+		MonsterCount: int32(game.GetMonsterCount()),
+		ItemCount: int32(game.GetItemCount()),
+		HasDoors: game.HasDoors(),
+	}
+}
+
+// The `mutex` must be locked by the caller.
+func (room *Room) hasJoined(playerID string) bool {
+	for _, hero := range room.state.GetHeroes() {
+		if hero.GetPlayerID() == playerID {
+			return true
+		}
+	}
+	return false
+}
+
+// Return false if the player has already joined. The check and the addition are under the same lock,
+// so that concurrent joins of the same player do not both pass the check.
+func (room *Room) join(playerID string) (bool, error) {
+	room.mutex.Lock()
+	defer room.mutex.Unlock()
+	if room.hasJoined(playerID) {
+		return false, nil
+	}
+	_, err := room.state.AddHero(playerID)
+	return true, errors.WithStack(err)
+}
+
+// Return true if nobody is in the room after leaving.
+func (room *Room) leave(playerID string) (bool, error) {
+	room.mutex.Lock()
+	defer room.mutex.Unlock()
+	err := room.state.RemoveHero(playerID)
+	if err != nil {
+		return false, errors.WithStack(err)
+	}
+	return len(room.state.GetHeroes()) == 0, nil
+}
+
+// The `mutex` must be locked by the caller.
+func (room *Room) createScreenPropsMessage(playerID string) (*pb.ScreenProps, error) {
+	screenProps, err := controller.MapStateModelToScreenProps(room.state, playerID)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	message := mapScreenPropsToScreenPropsMessage(screenProps)
+	message.IsGameFinished = room.state.GetGame().IsFinished()
+	return message, nil
+}
+
+func (room *Room) subscribeScreenProps(playerID string) chan *pb.ScreenProps {
+	room.mutex.Lock()
+	defer room.mutex.Unlock()
+	// It has a buffer of one screen, so that the main loop is not blocked by slow clients.
+	subscriber := make(chan *pb.ScreenProps, 1)
+	room.screenPropsSubscribers[subscriber] = playerID
+	return subscriber
+}
+
+func (room *Room) unsubscribeScreenProps(subscriber chan *pb.ScreenProps) {
+	room.mutex.Lock()
+	defer room.mutex.Unlock()
+	delete(room.screenPropsSubscribers, subscriber)
+}
+
+func (room *Room) subscribeFrames() chan struct{} {
+	room.mutex.Lock()
+	defer room.mutex.Unlock()
+	// Notifications are not queued more than one, the same as screens.
+	subscriber := make(chan struct{}, 1)
+	room.frameSubscribers[subscriber] = true
+	return subscriber
+}
+
+func (room *Room) unsubscribeFrames(subscriber chan struct{}) {
+	room.mutex.Lock()
+	defer room.mutex.Unlock()
+	delete(room.frameSubscribers, subscriber)
+}
+
+// The `mutex` must be locked by the caller.
+func (room *Room) notifyFrame() {
+	for subscriber := range room.frameSubscribers {
+		select {
+		case subscriber <- struct{}{}:
+		default:
+		}
+	}
+}
+
+// Create a snapshot for spectators.
+// If the player to follow is not in the room, the first player is followed instead.
+func (room *Room) createSpectateResponse(followPlayerID string) (*pb.SpectateResponse, error) {
+	room.mutex.Lock()
+	defer room.mutex.Unlock()
+	state := room.state
+	game := state.GetGame()
+	field := state.GetField()
+
+	heroes := state.GetHeroes()
+	followedPlayerID := ""
+	if room.hasJoined(followPlayerID) {
+		followedPlayerID = followPlayerID
+	} else if len(heroes) > 0 {
+		followedPlayerID = heroes[0].GetPlayerID()
+	}
+
+	players := make([]*pb.PlayerStatus, 0)
+	for _, hero := range heroes {
+		element, err := field.GetElementOfHero(hero.GetPlayerID())
+		if err != nil {
+			return nil, errors.WithStack(err)
+		}
+		players = append(players, &pb.PlayerStatus{
+			PlayerId: hero.GetPlayerID(),
+			Y: int32(element.GetPosition().GetY()),
+			X: int32(element.GetPosition().GetX()),
+			ClearedFloorCount: int32(hero.GetClearedFloorCount()),
+			HasReachedUpstairs: hero.HasReachedUpstairs(),
+		})
+	}
+
+	response := &pb.SpectateResponse{
+		FieldCells: mapScreenCellPropsToScreenCellRowMessages(
+			controller.MapFieldToScreenCellProps(field, followedPlayerID)),
+		Players: players,
+		RemainingTime: game.CalculateRemainingTime(state.GetExecutionTime()).Seconds(),
+		FloorNumber: int32(game.GetFloorNumber()),
+		Mode: mapGameModeToGameModeMessage(game.GetMode()),
+		FollowedPlayerId: followedPlayerID,
+	}
+	if followedPlayerID != "" {
+		message, err := room.createScreenPropsMessage(followedPlayerID)
+		if err != nil {
+			return nil, err
+		}
+		response.FollowedScreenProps = message
+	}
+	return response, nil
+}
+
+// Send the current screen to all subscribers.
+// If a subscriber has not received the previous screen yet, it is replaced by the current one.
+// Subscribers of players who have left receive nothing.
+// The `mutex` must be locked by the caller.
+func (room *Room) publishScreenProps() error {
+	messages := make(map[string]*pb.ScreenProps)
+	for subscriber, playerID := range room.screenPropsSubscribers {
+		if !room.hasJoined(playerID) {
+			continue
+		}
+		message, messageOk := messages[playerID]
+		if !messageOk {
+			var err error
+			message, err = room.createScreenPropsMessage(playerID)
+			if err != nil {
+				return err
+			}
+			messages[playerID] = message
+		}
+		select {
+		case <-subscriber:
+		default:
+		}
+		subscriber <- message
+	}
+	return nil
+}
+
+// Advance the game by one frame.
+// The RPCs apply inputs immediately, so the main loop only advances the time.
+func (room *Room) ProceedMainLoop(elapsedTime time.Duration) error {
+	room.mutex.Lock()
+	defer room.mutex.Unlock()
+	newState, err := reducers.AdvanceOnlyTime(*room.state, elapsedTime)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	room.state = newState
+	room.notifyFrame()
+	return room.publishScreenProps()
+}
+
+// Run the main loop until the room is closed.
+func (room *Room) runMainLoop(interval time.Duration) error {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	lastMainLoopRanAt := time.Now()
+	for {
+		select {
+		case <-room.closed:
+			return nil
+		case now := <-ticker.C:
+			err := room.ProceedMainLoop(now.Sub(lastMainLoopRanAt))
+			if err != nil {
+				return err
+			}
+			lastMainLoopRanAt = now
+		}
+	}
+}
+
+func (room *Room) close() {
+	room.closeOnce.Do(func() {
+		close(room.closed)
+	})
+}
+
+// The `mutex` must be locked by the caller.
+func (room *Room) startOrRestartGame() error {
+	seed := room.seed
+	if seed == 0 {
+		seed = rand.Int63()
+	}
+	newState, err := reducers.StartOrRestartGame(*room.state, 0, seed)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	room.state = newState
+	room.scoreSubmittedPlayerIDs = make(map[string]bool)
+	return nil
+}
+
+// Apply an input of a player to the state.
+// The `mutex` must be locked by the caller.
+func (room *Room) applyPlayInput(playerID string, inputType pb.PlayInputType) error {
+	var newState *models.State
+	var err error
+	switch inputType {
+	case pb.PlayInputType_PLAY_INPUT_TYPE_START_OR_RESTART_GAME:
+		return room.startOrRestartGame()
+	case pb.PlayInputType_PLAY_INPUT_TYPE_WALK_HERO_UP:
+		newState, err = reducers.WalkHero(*room.state, 0, playerID, reducers.FourDirectionUp)
+	case pb.PlayInputType_PLAY_INPUT_TYPE_WALK_HERO_RIGHT:
+		newState, err = reducers.WalkHero(*room.state, 0, playerID, reducers.FourDirectionRight)
+	case pb.PlayInputType_PLAY_INPUT_TYPE_WALK_HERO_DOWN:
+		newState, err = reducers.WalkHero(*room.state, 0, playerID, reducers.FourDirectionDown)
+	case pb.PlayInputType_PLAY_INPUT_TYPE_WALK_HERO_LEFT:
+		newState, err = reducers.WalkHero(*room.state, 0, playerID, reducers.FourDirectionLeft)
+	case pb.PlayInputType_PLAY_INPUT_TYPE_DIG_WALL_UP:
+		newState, err = reducers.DigWall(*room.state, 0, playerID, reducers.FourDirectionUp)
+	case pb.PlayInputType_PLAY_INPUT_TYPE_DIG_WALL_RIGHT:
+		newState, err = reducers.DigWall(*room.state, 0, playerID, reducers.FourDirectionRight)
+	case pb.PlayInputType_PLAY_INPUT_TYPE_DIG_WALL_DOWN:
+		newState, err = reducers.DigWall(*room.state, 0, playerID, reducers.FourDirectionDown)
+	case pb.PlayInputType_PLAY_INPUT_TYPE_DIG_WALL_LEFT:
+		newState, err = reducers.DigWall(*room.state, 0, playerID, reducers.FourDirectionLeft)
+	case pb.PlayInputType_PLAY_INPUT_TYPE_USE_ITEM_1:
+		newState, err = reducers.UseItem(*room.state, 0, playerID, 0)
+	case pb.PlayInputType_PLAY_INPUT_TYPE_USE_ITEM_2:
+		newState, err = reducers.UseItem(*room.state, 0, playerID, 1)
+	case pb.PlayInputType_PLAY_INPUT_TYPE_USE_ITEM_3:
+		newState, err = reducers.UseItem(*room.state, 0, playerID, 2)
+	case pb.PlayInputType_PLAY_INPUT_TYPE_USE_ITEM_4:
+		newState, err = reducers.UseItem(*room.state, 0, playerID, 3)
+	case pb.PlayInputType_PLAY_INPUT_TYPE_USE_ITEM_5:
+		newState, err = reducers.UseItem(*room.state, 0, playerID, 4)
+	default:
+		return errors.Errorf("The %v input type is invalid.", inputType)
+	}
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	room.state = newState
+	return nil
+}
+
+func createRoom(id string, name string, mode models.GameMode, seed int64, options *models.GameOptions) (*Room, error) {
+	state := models.CreateState()
+	state.GetGame().SetMode(mode)
+	applyOptionsErr := state.GetGame().ApplyOptions(options)
+	if applyOptionsErr != nil {
+		return nil, errors.WithStack(applyOptionsErr)
+	}
+	setWelcomeDataErr := state.SetWelcomeData()
+	if setWelcomeDataErr != nil {
+		return nil, errors.WithStack(setWelcomeDataErr)
+	}
+	return &Room{
+		id: id,
+		name: name,
+		seed: seed,
+		state: state,
+		screenPropsSubscribers: make(map[chan *pb.ScreenProps]string),
+		frameSubscribers: make(map[chan struct{}]bool),
+		scoreSubmittedPlayerIDs: make(map[string]bool),
+		closed: make(chan struct{}),
+	}, nil
+}
+
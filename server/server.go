@@ -0,0 +1,531 @@
+package server
+
+//
+// The "server" package hosts authoritative `models.State`s in rooms and exposes the reducers via gRPC.
+// Clients only send inputs and render screens that the server made.
+//
+
+import (
+	"context"
+	"fmt"
+	"github.com/kjirou/gRPC-sample-net-game/leaderboard"
+	"github.com/kjirou/gRPC-sample-net-game/models"
+	pb "github.com/kjirou/gRPC-sample-net-game/proto"
+	"github.com/kjirou/gRPC-sample-net-game/reducers"
+	"github.com/kjirou/gRPC-sample-net-game/utils"
+	"github.com/kjirou/gRPC-sample-net-game/views"
+	"github.com/pkg/errors"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"io"
+	"log"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// About 60fps. It is the same as the client's main loop.
+var MainLoopInterval = time.Microsecond*16666
+
+// The maximum number of scores that GetTopScores returns.
+const TopScoresLimit = 100
+
+func mapFourDirectionMessageToFourDirection(direction pb.FourDirection) (reducers.FourDirection, error) {
+	switch direction {
+	case pb.FourDirection_FOUR_DIRECTION_UP:
+		return reducers.FourDirectionUp, nil
+	case pb.FourDirection_FOUR_DIRECTION_RIGHT:
+		return reducers.FourDirectionRight, nil
+	case pb.FourDirection_FOUR_DIRECTION_DOWN:
+		return reducers.FourDirectionDown, nil
+	case pb.FourDirection_FOUR_DIRECTION_LEFT:
+		return reducers.FourDirectionLeft, nil
+	}
+	return reducers.FourDirectionUp, errors.Errorf("The %v direction is invalid.", direction)
+}
+
+func mapGameModeMessageToGameMode(mode pb.GameMode) (models.GameMode, error) {
+	switch mode {
+	case pb.GameMode_GAME_MODE_STANDARD:
+		return models.GameModeStandard, nil
+	case pb.GameMode_GAME_MODE_RACE:
+		return models.GameModeRace, nil
+	case pb.GameMode_GAME_MODE_COOP:
+		return models.GameModeCoop, nil
+	}
+	return models.GameModeStandard, errors.Errorf("The %v game mode is invalid.", mode)
+}
+
+func mapGameModeToGameModeMessage(mode models.GameMode) pb.GameMode {
+	switch mode {
+	case models.GameModeRace:
+		return pb.GameMode_GAME_MODE_RACE
+	case models.GameModeCoop:
+		return pb.GameMode_GAME_MODE_COOP
+	}
+	return pb.GameMode_GAME_MODE_STANDARD
+}
+
+func mapScreenCellPropsToScreenCellRowMessages(cellPropsMatrix [][]*views.ScreenCellProps) []*pb.ScreenCellRow {
+	rows := make([]*pb.ScreenCellRow, len(cellPropsMatrix))
+	for y, cellsRow := range cellPropsMatrix {
+		cells := make([]*pb.ScreenCell, len(cellsRow))
+		for x, cellProps := range cellsRow {
+			cells[x] = &pb.ScreenCell{
+				Symbol: int32(cellProps.Symbol),
+				Foreground: uint32(cellProps.Foreground),
+				Background: uint32(cellProps.Background),
+			}
+		}
+		rows[y] = &pb.ScreenCellRow{Cells: cells}
+	}
+	return rows
+}
+
+func mapScreenPropsToScreenPropsMessage(screenProps *views.ScreenProps) *pb.ScreenProps {
+	return &pb.ScreenProps{
+		FieldCells: mapScreenCellPropsToScreenCellRowMessages(screenProps.FieldCells),
+		FloorNumber: int32(screenProps.FloorNumber),
+		LankMessage: screenProps.LankMessage,
+		LankMessageForeground: uint32(screenProps.LankMessageForeground),
+		SideLines: screenProps.SideLines,
+		RemainingTime: screenProps.RemainingTime,
+		Seed: screenProps.Seed,
+		DigCharges: int32(screenProps.DigCharges),
+		InventoryItemNames: screenProps.InventoryItemNames,
+		KeyNames: screenProps.KeyNames,
+	}
+}
+
+func mapScoreToScoreMessage(score *leaderboard.Score) *pb.Score {
+	return &pb.Score{
+		PlayerName: score.PlayerName,
+		Floors: int32(score.Floors),
+		AchievedAt: score.AchievedAt.Unix(),
+		Seed: score.Seed,
+		Mode: mapGameModeToGameModeMessage(score.Mode),
+	}
+}
+
+type GameServer struct {
+	pb.UnimplementedGameServiceServer
+	// If it is zero, rooms do not run main loops. It is for tests that advance rooms manually.
+	mainLoopInterval time.Duration
+	// It guards the `rooms`, the `roomIDs` and the `lastRoomNumber`.
+	mutex sync.Mutex
+	rooms map[string]*Room
+	// Room IDs in the order of creation.
+	roomIDs []string
+	lastRoomNumber int
+	// The leaderboard shared by all rooms.
+	scoreStore leaderboard.Store
+}
+
+func (gameServer *GameServer) GetRoom(roomID string) (*Room, bool) {
+	gameServer.mutex.Lock()
+	defer gameServer.mutex.Unlock()
+	room, ok := gameServer.rooms[roomID]
+	return room, ok
+}
+
+func (gameServer *GameServer) findRoom(roomID string) (*Room, error) {
+	room, ok := gameServer.GetRoom(roomID)
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "The %q room does not exist.", roomID)
+	}
+	return room, nil
+}
+
+// Find the room where the player has joined, and lock it.
+// The caller must unlock the room's `mutex` if it does not return an error.
+func (gameServer *GameServer) lockRoomOfPlayer(roomID string, playerID string) (*Room, error) {
+	room, err := gameServer.findRoom(roomID)
+	if err != nil {
+		return nil, err
+	}
+	room.mutex.Lock()
+	if !room.hasJoined(playerID) {
+		room.mutex.Unlock()
+		return nil, status.Errorf(codes.NotFound, "The %q player has not joined the %q room.", playerID, roomID)
+	}
+	return room, nil
+}
+
+func (gameServer *GameServer) closeRoom(roomID string) {
+	gameServer.mutex.Lock()
+	defer gameServer.mutex.Unlock()
+	room, ok := gameServer.rooms[roomID]
+	if !ok {
+		return
+	}
+	room.close()
+	delete(gameServer.rooms, roomID)
+	roomIDs := make([]string, 0)
+	for _, id := range gameServer.roomIDs {
+		if id != roomID {
+			roomIDs = append(roomIDs, id)
+		}
+	}
+	gameServer.roomIDs = roomIDs
+}
+
+// Close all rooms and stop their main loops.
+func (gameServer *GameServer) Close() {
+	gameServer.mutex.Lock()
+	roomIDs := gameServer.roomIDs
+	gameServer.mutex.Unlock()
+	for _, roomID := range roomIDs {
+		gameServer.closeRoom(roomID)
+	}
+}
+
+func (gameServer *GameServer) CreateRoom(
+	ctx context.Context, request *pb.CreateRoomRequest) (*pb.CreateRoomResponse, error) {
+	mode, modeErr := mapGameModeMessageToGameMode(request.GetMode())
+	if modeErr != nil {
+		return nil, status.Error(codes.InvalidArgument, modeErr.Error())
+	}
+	for _, mazeGeneratorName := range request.GetMazeGeneratorNames() {
+		_, findErr := utils.FindMazeGenerator(mazeGeneratorName)
+		if findErr != nil {
+			return nil, status.Error(codes.InvalidArgument, findErr.Error())
+		}
+	}
+	if request.GetLoopDensity() < 0 || request.GetLoopDensity() > 1 {
+		return nil, status.Errorf(codes.InvalidArgument, "The loop density must be between 0 and 1.")
+	}
+	if request.GetMonsterCount() < 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "The number of monsters must not be negative.")
+	}
+	if request.GetItemCount() < 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "The number of items must not be negative.")
+	}
+	stairsPlacement := models.StairsPlacementFixed
+	if request.GetStairsPlacement() != "" {
+		var findErr error
+		stairsPlacement, findErr = models.FindStairsPlacement(request.GetStairsPlacement())
+		if findErr != nil {
+			return nil, status.Error(codes.InvalidArgument, findErr.Error())
+		}
+	}
+	gameServer.mutex.Lock()
+	defer gameServer.mutex.Unlock()
+	gameServer.lastRoomNumber++
+	roomID := strconv.Itoa(gameServer.lastRoomNumber)
+	name := request.GetName()
+	if name == "" {
+		name = fmt.Sprintf("Room %s", roomID)
+	}
+	room, err := createRoom(roomID, name, mode, request.GetSeed(), &models.GameOptions{
+		MazeGeneratorNames: request.GetMazeGeneratorNames(),
+		LoopDensity: request.GetLoopDensity(),
+		StairsPlacement: stairsPlacement,
+		TargetsDifficulty: request.GetTargetsDifficulty(),
+		MonsterCount: int(request.GetMonsterCount()),
+		ItemCount: int(request.GetItemCount()),
+		HasDoors: request.GetHasDoors(),
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "%+v", err)
+	}
+	gameServer.rooms[roomID] = room
+	gameServer.roomIDs = append(gameServer.roomIDs, roomID)
+	if gameServer.mainLoopInterval > 0 {
+		go func() {
+			runMainLoopErr := room.runMainLoop(gameServer.mainLoopInterval)
+			if runMainLoopErr != nil {
+				log.Printf("The %q room is closed by an error: %+v", roomID, runMainLoopErr)
+				gameServer.closeRoom(roomID)
+			}
+		}()
+	}
+	return &pb.CreateRoomResponse{Room: room.toMessage()}, nil
+}
+
+func (gameServer *GameServer) ListRooms(ctx context.Context, request *pb.ListRoomsRequest) (*pb.ListRoomsResponse, error) {
+	gameServer.mutex.Lock()
+	rooms := make([]*Room, 0)
+	for _, roomID := range gameServer.roomIDs {
+		rooms = append(rooms, gameServer.rooms[roomID])
+	}
+	gameServer.mutex.Unlock()
+	roomMessages := make([]*pb.Room, 0)
+	for _, room := range rooms {
+		roomMessages = append(roomMessages, room.toMessage())
+	}
+	return &pb.ListRoomsResponse{Rooms: roomMessages}, nil
+}
+
+func (gameServer *GameServer) JoinRoom(ctx context.Context, request *pb.JoinRoomRequest) (*pb.JoinRoomResponse, error) {
+	if request.GetPlayerId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "The player ID is empty.")
+	}
+	room, err := gameServer.findRoom(request.GetRoomId())
+	if err != nil {
+		return nil, err
+	}
+	joined, joinErr := room.join(request.GetPlayerId())
+	if joinErr != nil {
+		return nil, status.Errorf(codes.ResourceExhausted, "%+v", joinErr)
+	} else if !joined {
+		return nil, status.Errorf(
+			codes.AlreadyExists, "The %q player has already joined the %q room.", request.GetPlayerId(), room.GetID())
+	}
+	return &pb.JoinRoomResponse{}, nil
+}
+
+func (gameServer *GameServer) LeaveRoom(ctx context.Context, request *pb.LeaveRoomRequest) (*pb.LeaveRoomResponse, error) {
+	room, err := gameServer.findRoom(request.GetRoomId())
+	if err != nil {
+		return nil, err
+	}
+	isEmpty, leaveErr := room.leave(request.GetPlayerId())
+	if leaveErr != nil {
+		return nil, status.Errorf(
+			codes.NotFound, "The %q player has not joined the %q room.", request.GetPlayerId(), room.GetID())
+	}
+	if isEmpty {
+		gameServer.closeRoom(room.GetID())
+	}
+	return &pb.LeaveRoomResponse{}, nil
+}
+
+func (gameServer *GameServer) StartOrRestartGame(
+	ctx context.Context, request *pb.StartOrRestartGameRequest) (*pb.StartOrRestartGameResponse, error) {
+	room, err := gameServer.findRoom(request.GetRoomId())
+	if err != nil {
+		return nil, err
+	}
+	room.mutex.Lock()
+	defer room.mutex.Unlock()
+	startErr := room.startOrRestartGame()
+	if startErr != nil {
+		return nil, status.Errorf(codes.Internal, "%+v", startErr)
+	}
+	return &pb.StartOrRestartGameResponse{}, nil
+}
+
+func (gameServer *GameServer) WalkHero(ctx context.Context, request *pb.WalkHeroRequest) (*pb.WalkHeroResponse, error) {
+	direction, directionErr := mapFourDirectionMessageToFourDirection(request.GetDirection())
+	if directionErr != nil {
+		return nil, status.Error(codes.InvalidArgument, directionErr.Error())
+	}
+	room, err := gameServer.lockRoomOfPlayer(request.GetRoomId(), request.GetPlayerId())
+	if err != nil {
+		return nil, err
+	}
+	defer room.mutex.Unlock()
+	newState, walkErr := reducers.WalkHero(*room.state, 0, request.GetPlayerId(), direction)
+	if walkErr != nil {
+		return nil, status.Errorf(codes.Internal, "%+v", walkErr)
+	}
+	room.state = newState
+	return &pb.WalkHeroResponse{}, nil
+}
+
+func (gameServer *GameServer) GetScreen(ctx context.Context, request *pb.GetScreenRequest) (*pb.GetScreenResponse, error) {
+	room, err := gameServer.lockRoomOfPlayer(request.GetRoomId(), request.GetPlayerId())
+	if err != nil {
+		return nil, err
+	}
+	defer room.mutex.Unlock()
+	message, messageErr := room.createScreenPropsMessage(request.GetPlayerId())
+	if messageErr != nil {
+		return nil, status.Errorf(codes.Internal, "%+v", messageErr)
+	}
+	return &pb.GetScreenResponse{
+		ScreenProps: message,
+	}, nil
+}
+
+// Push screens until the client cancels or the room is closed.
+func (gameServer *GameServer) StreamState(request *pb.StreamStateRequest, stream pb.GameService_StreamStateServer) error {
+	playerID := request.GetPlayerId()
+	room, err := gameServer.lockRoomOfPlayer(request.GetRoomId(), playerID)
+	if err != nil {
+		return err
+	}
+	// Send the current screen at once, in order not to wait for the next main loop.
+	message, messageErr := room.createScreenPropsMessage(playerID)
+	room.mutex.Unlock()
+	if messageErr != nil {
+		return status.Errorf(codes.Internal, "%+v", messageErr)
+	}
+
+	subscriber := room.subscribeScreenProps(playerID)
+	defer room.unsubscribeScreenProps(subscriber)
+
+	sendErr := stream.Send(&pb.StreamStateResponse{ScreenProps: message})
+	if sendErr != nil {
+		return sendErr
+	}
+
+	for {
+		select {
+		case <-stream.Context().Done():
+			return nil
+		case <-room.closed:
+			return nil
+		case message := <-subscriber:
+			sendErr := stream.Send(&pb.StreamStateResponse{ScreenProps: message})
+			if sendErr != nil {
+				return sendErr
+			}
+		}
+	}
+}
+
+func (gameServer *GameServer) Play(stream pb.GameService_PlayServer) error {
+	for {
+		request, recvErr := stream.Recv()
+		if recvErr == io.EOF {
+			return nil
+		} else if recvErr != nil {
+			return recvErr
+		}
+
+		inputType := request.GetInputType()
+		if _, ok := pb.PlayInputType_name[int32(inputType)]; !ok || inputType == pb.PlayInputType_PLAY_INPUT_TYPE_UNSPECIFIED {
+			return status.Errorf(codes.InvalidArgument, "The %v input type is invalid.", inputType)
+		}
+		room, err := gameServer.lockRoomOfPlayer(request.GetRoomId(), request.GetPlayerId())
+		if err != nil {
+			return err
+		}
+		applyErr := room.applyPlayInput(request.GetPlayerId(), inputType)
+		room.mutex.Unlock()
+		if applyErr != nil {
+			return status.Errorf(codes.Internal, "%+v", applyErr)
+		}
+
+		sendErr := stream.Send(&pb.PlayResponse{SequenceNumber: request.GetSequenceNumber()})
+		if sendErr != nil {
+			return sendErr
+		}
+	}
+}
+
+// Send snapshots of the room every main loop until the client cancels or the room is closed.
+// Requests after the first one only switch the player to follow.
+func (gameServer *GameServer) Spectate(stream pb.GameService_SpectateServer) error {
+	firstRequest, firstRecvErr := stream.Recv()
+	if firstRecvErr != nil {
+		return firstRecvErr
+	}
+	room, err := gameServer.findRoom(firstRequest.GetRoomId())
+	if err != nil {
+		return err
+	}
+
+	var followMutex sync.Mutex
+	followPlayerID := firstRequest.GetFollowPlayerId()
+	recvErrs := make(chan error, 1)
+	go func() {
+		for {
+			request, recvErr := stream.Recv()
+			if recvErr != nil {
+				recvErrs <- recvErr
+				return
+			}
+			followMutex.Lock()
+			followPlayerID = request.GetFollowPlayerId()
+			followMutex.Unlock()
+		}
+	}()
+
+	frameSubscriber := room.subscribeFrames()
+	defer room.unsubscribeFrames(frameSubscriber)
+
+	sendSnapshot := func() error {
+		followMutex.Lock()
+		currentFollowPlayerID := followPlayerID
+		followMutex.Unlock()
+		response, createErr := room.createSpectateResponse(currentFollowPlayerID)
+		if createErr != nil {
+			return status.Errorf(codes.Internal, "%+v", createErr)
+		}
+		return stream.Send(response)
+	}
+
+	// Send the current snapshot at once, in order not to wait for the next main loop.
+	sendErr := sendSnapshot()
+	if sendErr != nil {
+		return sendErr
+	}
+	for {
+		select {
+		case <-stream.Context().Done():
+			return nil
+		case <-room.closed:
+			return nil
+		case recvErr := <-recvErrs:
+			if recvErr == io.EOF {
+				return nil
+			}
+			return recvErr
+		case <-frameSubscriber:
+			sendErr := sendSnapshot()
+			if sendErr != nil {
+				return sendErr
+			}
+		}
+	}
+}
+
+// Each player can submit a score once per game.
+func (gameServer *GameServer) SubmitScore(
+	ctx context.Context, request *pb.SubmitScoreRequest) (*pb.SubmitScoreResponse, error) {
+	playerID := request.GetPlayerId()
+	room, err := gameServer.lockRoomOfPlayer(request.GetRoomId(), playerID)
+	if err != nil {
+		return nil, err
+	}
+	defer room.mutex.Unlock()
+	if !room.state.GetGame().IsFinished() {
+		return nil, status.Errorf(codes.FailedPrecondition, "The game of the %q room has not finished yet.", room.GetID())
+	} else if room.scoreSubmittedPlayerIDs[playerID] {
+		return nil, status.Errorf(codes.AlreadyExists, "The %q player has already submitted the score.", playerID)
+	}
+	score, scoreErr := leaderboard.CreateScoreOfHero(room.state, playerID, time.Now())
+	if scoreErr != nil {
+		return nil, status.Errorf(codes.Internal, "%+v", scoreErr)
+	}
+	submitErr := gameServer.scoreStore.SubmitScore(score)
+	if submitErr != nil {
+		return nil, status.Errorf(codes.Internal, "%+v", submitErr)
+	}
+	room.scoreSubmittedPlayerIDs[playerID] = true
+	return &pb.SubmitScoreResponse{
+		Score: mapScoreToScoreMessage(score),
+	}, nil
+}
+
+func (gameServer *GameServer) GetTopScores(
+	ctx context.Context, request *pb.GetTopScoresRequest) (*pb.GetTopScoresResponse, error) {
+	limit := int(request.GetLimit())
+	if limit <= 0 || limit > TopScoresLimit {
+		limit = TopScoresLimit
+	}
+	scores, err := gameServer.scoreStore.GetTopScores(limit)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "%+v", err)
+	}
+	messages := make([]*pb.Score, len(scores))
+	for index, score := range scores {
+		messages[index] = mapScoreToScoreMessage(score)
+	}
+	return &pb.GetTopScoresResponse{
+		Scores: messages,
+	}, nil
+}
+
+// The `mainLoopInterval` is the interval of each room's main loop.
+// If it is zero, rooms do not run main loops.
+func CreateGameServer(mainLoopInterval time.Duration, scoreStore leaderboard.Store) *GameServer {
+	return &GameServer{
+		mainLoopInterval: mainLoopInterval,
+		scoreStore: scoreStore,
+		rooms: make(map[string]*Room),
+		roomIDs: make([]string, 0),
+	}
+}
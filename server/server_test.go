@@ -0,0 +1,606 @@
+package server
+
+import (
+	"context"
+	"github.com/kjirou/gRPC-sample-net-game/leaderboard"
+	"github.com/kjirou/gRPC-sample-net-game/models"
+	pb "github.com/kjirou/gRPC-sample-net-game/proto"
+	"github.com/kjirou/gRPC-sample-net-game/utils"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+const testingPlayerID = "tester"
+
+// Create a leaderboard in a temporary directory that is removed after the test.
+func createTestingScoreStore(t *testing.T) *leaderboard.FileStore {
+	directory, err := ioutil.TempDir("", "server")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		os.RemoveAll(directory)
+	})
+	return leaderboard.CreateFileStore(filepath.Join(directory, leaderboard.DefaultFileName))
+}
+
+// Start the game server on an in-memory listener, and return a client connected to it.
+// Rooms do not run main loops, so tests advance them manually.
+func startTestingServer(t *testing.T) (*GameServer, pb.GameServiceClient) {
+	gameServer := CreateGameServer(0, createTestingScoreStore(t))
+	listener := bufconn.Listen(1024*1024)
+	grpcServer := grpc.NewServer()
+	pb.RegisterGameServiceServer(grpcServer, gameServer)
+	go grpcServer.Serve(listener)
+	connection, dialErr := grpc.DialContext(
+		context.Background(),
+		"bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, address string) (net.Conn, error) {
+			return listener.Dial()
+		}),
+		grpc.WithInsecure())
+	if dialErr != nil {
+		t.Fatal(dialErr)
+	}
+	t.Cleanup(func() {
+		connection.Close()
+		grpcServer.Stop()
+		gameServer.Close()
+	})
+	return gameServer, pb.NewGameServiceClient(connection)
+}
+
+// Start the game server with a room, and return the room and a client that has joined it as the `testingPlayerID`.
+func startTestingRoom(t *testing.T) (*Room, pb.GameServiceClient) {
+	gameServer, client := startTestingServer(t)
+	response, createRoomErr := client.CreateRoom(context.Background(), &pb.CreateRoomRequest{})
+	if createRoomErr != nil {
+		t.Fatal(createRoomErr)
+	}
+	_, joinErr := client.JoinRoom(context.Background(), &pb.JoinRoomRequest{
+		RoomId: response.GetRoom().GetRoomId(),
+		PlayerId: testingPlayerID,
+	})
+	if joinErr != nil {
+		t.Fatal(joinErr)
+	}
+	room, _ := gameServer.GetRoom(response.GetRoom().GetRoomId())
+	return room, client
+}
+
+func TestGameServer_NotTD(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("GetScreen はゲーム開始前の画面を返す", func(t *testing.T) {
+		room, client := startTestingRoom(t)
+		response, err := client.GetScreen(ctx, &pb.GetScreenRequest{PlayerId: testingPlayerID, RoomId: room.GetID()})
+		if err != nil {
+			t.Fatal(err)
+		}
+		screenProps := response.GetScreenProps()
+		if len(screenProps.GetFieldCells()) != 13 || len(screenProps.GetFieldCells()[0].GetCells()) != 21 {
+			t.Fatal("フィールドの大きさが違う")
+		} else if screenProps.GetFloorNumber() != 1 {
+			t.Fatal("階数が 1 ではない")
+		} else if screenProps.GetRemainingTime() != 30 {
+			t.Fatal("残り時間が 30 ではない")
+		}
+		centerCell := screenProps.GetFieldCells()[6].GetCells()[10]
+		if rune(centerCell.GetSymbol()) != '@' {
+			t.Fatal("中央にヒーローが表示されていない")
+		}
+	})
+
+	t.Run("StartOrRestartGame でサーバ上のゲームが開始する", func(t *testing.T) {
+		room, client := startTestingRoom(t)
+		_, err := client.StartOrRestartGame(ctx, &pb.StartOrRestartGameRequest{RoomId: room.GetID()})
+		if err != nil {
+			t.Fatal(err)
+		}
+		proceedErr := room.ProceedMainLoop(time.Second)
+		if proceedErr != nil {
+			t.Fatal(proceedErr)
+		}
+		response, _ := client.GetScreen(ctx, &pb.GetScreenRequest{PlayerId: testingPlayerID, RoomId: room.GetID()})
+		if response.GetScreenProps().GetRemainingTime() != 29 {
+			t.Fatal("サーバ上で時間が進んでいない")
+		}
+	})
+
+	t.Run("WalkHero でサーバ上のヒーローが移動する", func(t *testing.T) {
+		room, client := startTestingRoom(t)
+		client.StartOrRestartGame(ctx, &pb.StartOrRestartGameRequest{RoomId: room.GetID()})
+		// The hero can go right or down from the entrance in any mazes.
+		field := room.GetState().GetField()
+		direction := pb.FourDirection_FOUR_DIRECTION_RIGHT
+		expectedElement := &models.FieldElement{}
+		rightElement, _ := field.At(&utils.MatrixPosition{Y: models.HeroPosition.GetY(), X: models.HeroPosition.GetX() + 1})
+		if rightElement.IsObjectEmpty() {
+			expectedElement = rightElement
+		} else {
+			direction = pb.FourDirection_FOUR_DIRECTION_DOWN
+			expectedElement, _ = field.At(&utils.MatrixPosition{Y: models.HeroPosition.GetY() + 1, X: models.HeroPosition.GetX()})
+		}
+		_, err := client.WalkHero(ctx, &pb.WalkHeroRequest{Direction: direction, PlayerId: testingPlayerID, RoomId: room.GetID()})
+		if err != nil {
+			t.Fatal(err)
+		}
+		heroElement, _ := room.GetState().GetField().GetElementOfHero(testingPlayerID)
+		if heroElement != expectedElement {
+			t.Fatal("ヒーローが移動していない")
+		}
+	})
+
+	t.Run("WalkHero に不正な方向を渡すとエラーを返す", func(t *testing.T) {
+		room, client := startTestingRoom(t)
+		_, err := client.WalkHero(ctx, &pb.WalkHeroRequest{Direction: pb.FourDirection(99), PlayerId: testingPlayerID, RoomId: room.GetID()})
+		if err == nil {
+			t.Fatal("エラーを返さない")
+		}
+	})
+
+	t.Run("StreamState は接続直後とメインループ毎に画面を送る", func(t *testing.T) {
+		room, client := startTestingRoom(t)
+		client.StartOrRestartGame(ctx, &pb.StartOrRestartGameRequest{RoomId: room.GetID()})
+		streamCtx, cancel := context.WithCancel(ctx)
+		defer cancel()
+		stream, err := client.StreamState(streamCtx, &pb.StreamStateRequest{PlayerId: testingPlayerID, RoomId: room.GetID()})
+		if err != nil {
+			t.Fatal(err)
+		}
+		firstResponse, firstRecvErr := stream.Recv()
+		if firstRecvErr != nil {
+			t.Fatal(firstRecvErr)
+		} else if firstResponse.GetScreenProps().GetRemainingTime() != 30 {
+			t.Fatal("接続直後の画面ではない")
+		}
+		room.ProceedMainLoop(time.Second)
+		secondResponse, secondRecvErr := stream.Recv()
+		if secondRecvErr != nil {
+			t.Fatal(secondRecvErr)
+		} else if secondResponse.GetScreenProps().GetRemainingTime() != 29 {
+			t.Fatal("メインループ後の画面ではない")
+		}
+	})
+
+	t.Run("Play は入力を適用して同じシーケンス番号の応答を返す", func(t *testing.T) {
+		room, client := startTestingRoom(t)
+		stream, err := client.Play(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		stream.Send(&pb.PlayRequest{
+			SequenceNumber: 1,
+			PlayerId: testingPlayerID,
+			RoomId: room.GetID(),
+			InputType: pb.PlayInputType_PLAY_INPUT_TYPE_START_OR_RESTART_GAME,
+		})
+		response, recvErr := stream.Recv()
+		if recvErr != nil {
+			t.Fatal(recvErr)
+		} else if response.GetSequenceNumber() != 1 {
+			t.Fatal("シーケンス番号が違う")
+		} else if !room.GetState().GetGame().IsStarted() {
+			t.Fatal("ゲームが開始していない")
+		}
+		stream.CloseSend()
+	})
+
+	t.Run("Play に不正な入力を送るとエラーで終了する", func(t *testing.T) {
+		room, client := startTestingRoom(t)
+		stream, _ := client.Play(ctx)
+		stream.Send(&pb.PlayRequest{
+			SequenceNumber: 1,
+			PlayerId: testingPlayerID,
+			RoomId: room.GetID(),
+			InputType: pb.PlayInputType_PLAY_INPUT_TYPE_UNSPECIFIED,
+		})
+		_, recvErr := stream.Recv()
+		if status.Code(recvErr) != codes.InvalidArgument {
+			t.Fatal("InvalidArgument のエラーを返さない")
+		}
+	})
+
+	t.Run("複数のプレイヤーが同じフィールドに参加できる", func(t *testing.T) {
+		room, client := startTestingRoom(t)
+		_, err := client.JoinRoom(ctx, &pb.JoinRoomRequest{PlayerId: "other", RoomId: room.GetID()})
+		if err != nil {
+			t.Fatal(err)
+		} else if len(room.GetState().GetHeroes()) != 2 {
+			t.Fatal("ヒーローが 2 人いない")
+		}
+		response, _ := client.GetScreen(ctx, &pb.GetScreenRequest{PlayerId: "other", RoomId: room.GetID()})
+		centerCell := response.GetScreenProps().GetFieldCells()[6].GetCells()[10]
+		if rune(centerCell.GetSymbol()) != '@' {
+			t.Fatal("中央に自分のヒーローが表示されていない")
+		}
+	})
+
+	t.Run("同じプレイヤーは重複して参加できない", func(t *testing.T) {
+		room, client := startTestingRoom(t)
+		_, err := client.JoinRoom(ctx, &pb.JoinRoomRequest{PlayerId: testingPlayerID, RoomId: room.GetID()})
+		if status.Code(err) != codes.AlreadyExists {
+			t.Fatal("AlreadyExists のエラーを返さない")
+		}
+	})
+
+	t.Run("同じプレイヤーが同時に参加しても1度だけ参加し、他は AlreadyExists になる", func(t *testing.T) {
+		room, client := startTestingRoom(t)
+		const joinCount = 10
+		codesChannel := make(chan codes.Code, joinCount)
+		for i := 0; i < joinCount; i++ {
+			go func() {
+				_, err := client.JoinRoom(ctx, &pb.JoinRoomRequest{PlayerId: "other", RoomId: room.GetID()})
+				codesChannel <- status.Code(err)
+			}()
+		}
+		okCount := 0
+		for i := 0; i < joinCount; i++ {
+			switch code := <-codesChannel; code {
+			case codes.OK:
+				okCount++
+			case codes.AlreadyExists:
+			default:
+				t.Fatalf("%v のエラーを返す", code)
+			}
+		}
+		if okCount != 1 {
+			t.Fatalf("%d 回参加している", okCount)
+		} else if len(room.GetState().GetHeroes()) != 2 {
+			t.Fatal("ヒーローの数が違う")
+		}
+	})
+
+	t.Run("参加していないプレイヤーの操作はエラーを返す", func(t *testing.T) {
+		room, client := startTestingRoom(t)
+		client.JoinRoom(ctx, &pb.JoinRoomRequest{PlayerId: "other", RoomId: room.GetID()})
+		client.LeaveRoom(ctx, &pb.LeaveRoomRequest{PlayerId: testingPlayerID, RoomId: room.GetID()})
+		_, err := client.WalkHero(ctx, &pb.WalkHeroRequest{
+			Direction: pb.FourDirection_FOUR_DIRECTION_RIGHT,
+			PlayerId: testingPlayerID,
+			RoomId: room.GetID(),
+		})
+		if status.Code(err) != codes.NotFound {
+			t.Fatal("NotFound のエラーを返さない")
+		}
+	})
+}
+
+func TestGameServer_Rooms_NotTD(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("ListRooms は作成した順に部屋と参加者を返す", func(t *testing.T) {
+		_, client := startTestingServer(t)
+		client.CreateRoom(ctx, &pb.CreateRoomRequest{Name: "a"})
+		createRoomResponse, _ := client.CreateRoom(ctx, &pb.CreateRoomRequest{Name: "b"})
+		client.JoinRoom(ctx, &pb.JoinRoomRequest{RoomId: createRoomResponse.GetRoom().GetRoomId(), PlayerId: "x"})
+		response, err := client.ListRooms(ctx, &pb.ListRoomsRequest{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		rooms := response.GetRooms()
+		if len(rooms) != 2 {
+			t.Fatal("部屋の数が違う")
+		} else if rooms[0].GetName() != "a" || rooms[1].GetName() != "b" {
+			t.Fatal("作成した順ではない")
+		} else if len(rooms[1].GetPlayerIds()) != 1 || rooms[1].GetPlayerIds()[0] != "x" {
+			t.Fatal("参加者が違う")
+		}
+	})
+
+	t.Run("レースモードの部屋を作成できる", func(t *testing.T) {
+		gameServer, client := startTestingServer(t)
+		response, err := client.CreateRoom(ctx, &pb.CreateRoomRequest{Mode: pb.GameMode_GAME_MODE_RACE})
+		if err != nil {
+			t.Fatal(err)
+		} else if response.GetRoom().GetMode() != pb.GameMode_GAME_MODE_RACE {
+			t.Fatal("レースモードではない")
+		}
+		room, _ := gameServer.GetRoom(response.GetRoom().GetRoomId())
+		if room.GetState().GetGame().GetMode() != models.GameModeRace {
+			t.Fatal("部屋の状態がレースモードではない")
+		}
+	})
+
+	t.Run("シードを指定した部屋では毎回同じシードでゲームを開始する", func(t *testing.T) {
+		gameServer, client := startTestingServer(t)
+		response, _ := client.CreateRoom(ctx, &pb.CreateRoomRequest{Seed: 123})
+		roomID := response.GetRoom().GetRoomId()
+		if response.GetRoom().GetSeed() != 123 {
+			t.Fatal("部屋がシードを持たない")
+		}
+		client.JoinRoom(ctx, &pb.JoinRoomRequest{RoomId: roomID, PlayerId: testingPlayerID})
+		room, _ := gameServer.GetRoom(roomID)
+		for i := 0; i < 2; i++ {
+			client.StartOrRestartGame(ctx, &pb.StartOrRestartGameRequest{RoomId: roomID})
+			screenResponse, _ := client.GetScreen(ctx, &pb.GetScreenRequest{PlayerId: testingPlayerID, RoomId: roomID})
+			if screenResponse.GetScreenProps().GetSeed() != 123 {
+				t.Fatal("指定したシードではない")
+			} else if !room.GetState().GetGame().IsStarted() {
+				t.Fatal("ゲームが開始していない")
+			}
+		}
+	})
+
+	t.Run("迷路の生成アルゴリズムを指定して部屋を作成できる", func(t *testing.T) {
+		_, client := startTestingServer(t)
+		response, err := client.CreateRoom(ctx, &pb.CreateRoomRequest{MazeGeneratorNames: []string{"prim", "eller"}})
+		if err != nil {
+			t.Fatal(err)
+		} else if len(response.GetRoom().GetMazeGeneratorNames()) != 2 {
+			t.Fatal("アルゴリズムが指定されていない")
+		}
+		_, invalidErr := client.CreateRoom(ctx, &pb.CreateRoomRequest{MazeGeneratorNames: []string{"unknown"}})
+		if status.Code(invalidErr) != codes.InvalidArgument {
+			t.Fatal("InvalidArgument のエラーを返さない")
+		}
+	})
+
+	t.Run("魔物の数を指定して部屋を作成できる", func(t *testing.T) {
+		_, client := startTestingServer(t)
+		response, err := client.CreateRoom(ctx, &pb.CreateRoomRequest{MonsterCount: 3})
+		if err != nil {
+			t.Fatal(err)
+		} else if response.GetRoom().GetMonsterCount() != 3 {
+			t.Fatal("指定されていない")
+		}
+		_, invalidErr := client.CreateRoom(ctx, &pb.CreateRoomRequest{MonsterCount: -1})
+		if status.Code(invalidErr) != codes.InvalidArgument {
+			t.Fatal("InvalidArgument のエラーを返さない")
+		}
+	})
+
+	t.Run("道具の数を指定して部屋を作成できる", func(t *testing.T) {
+		_, client := startTestingServer(t)
+		response, err := client.CreateRoom(ctx, &pb.CreateRoomRequest{ItemCount: 2})
+		if err != nil {
+			t.Fatal(err)
+		} else if response.GetRoom().GetItemCount() != 2 {
+			t.Fatal("指定されていない")
+		}
+		_, invalidErr := client.CreateRoom(ctx, &pb.CreateRoomRequest{ItemCount: -1})
+		if status.Code(invalidErr) != codes.InvalidArgument {
+			t.Fatal("InvalidArgument のエラーを返さない")
+		}
+	})
+
+	t.Run("難易度を目標にする部屋を作成できる", func(t *testing.T) {
+		_, client := startTestingServer(t)
+		response, err := client.CreateRoom(ctx, &pb.CreateRoomRequest{TargetsDifficulty: true})
+		if err != nil {
+			t.Fatal(err)
+		} else if !response.GetRoom().GetTargetsDifficulty() {
+			t.Fatal("指定されていない")
+		}
+	})
+
+	t.Run("扉のある部屋を作成できる", func(t *testing.T) {
+		_, client := startTestingServer(t)
+		response, err := client.CreateRoom(ctx, &pb.CreateRoomRequest{HasDoors: true})
+		if err != nil {
+			t.Fatal(err)
+		} else if !response.GetRoom().GetHasDoors() {
+			t.Fatal("指定されていない")
+		}
+	})
+
+	t.Run("階段の配置方法を指定して部屋を作成できる", func(t *testing.T) {
+		_, client := startTestingServer(t)
+		response, err := client.CreateRoom(ctx, &pb.CreateRoomRequest{StairsPlacement: "farthest"})
+		if err != nil {
+			t.Fatal(err)
+		} else if response.GetRoom().GetStairsPlacement() != "farthest" {
+			t.Fatal("配置方法が指定されていない")
+		}
+		defaultResponse, _ := client.CreateRoom(ctx, &pb.CreateRoomRequest{})
+		if defaultResponse.GetRoom().GetStairsPlacement() != "fixed" {
+			t.Fatal("既定の配置方法ではない")
+		}
+		_, invalidErr := client.CreateRoom(ctx, &pb.CreateRoomRequest{StairsPlacement: "unknown"})
+		if status.Code(invalidErr) != codes.InvalidArgument {
+			t.Fatal("InvalidArgument のエラーを返さない")
+		}
+	})
+
+	t.Run("ループの密度を指定して部屋を作成できる", func(t *testing.T) {
+		_, client := startTestingServer(t)
+		response, err := client.CreateRoom(ctx, &pb.CreateRoomRequest{LoopDensity: 0.25})
+		if err != nil {
+			t.Fatal(err)
+		} else if response.GetRoom().GetLoopDensity() != 0.25 {
+			t.Fatal("密度が指定されていない")
+		}
+		_, invalidErr := client.CreateRoom(ctx, &pb.CreateRoomRequest{LoopDensity: 2})
+		if status.Code(invalidErr) != codes.InvalidArgument {
+			t.Fatal("InvalidArgument のエラーを返さない")
+		}
+	})
+
+	t.Run("部屋ごとに独立した状態を持つ", func(t *testing.T) {
+		gameServer, client := startTestingServer(t)
+		responseA, _ := client.CreateRoom(ctx, &pb.CreateRoomRequest{})
+		responseB, _ := client.CreateRoom(ctx, &pb.CreateRoomRequest{})
+		roomA, _ := gameServer.GetRoom(responseA.GetRoom().GetRoomId())
+		roomB, _ := gameServer.GetRoom(responseB.GetRoom().GetRoomId())
+		roomA.ProceedMainLoop(time.Second)
+		if roomA.GetState().GetExecutionTime() == roomB.GetState().GetExecutionTime() {
+			t.Fatal("他の部屋の時間が進んでいる")
+		}
+	})
+
+	t.Run("全員が退出した部屋は閉じられる", func(t *testing.T) {
+		gameServer, client := startTestingServer(t)
+		response, _ := client.CreateRoom(ctx, &pb.CreateRoomRequest{})
+		roomID := response.GetRoom().GetRoomId()
+		client.JoinRoom(ctx, &pb.JoinRoomRequest{RoomId: roomID, PlayerId: "x"})
+		_, err := client.LeaveRoom(ctx, &pb.LeaveRoomRequest{RoomId: roomID, PlayerId: "x"})
+		if err != nil {
+			t.Fatal(err)
+		} else if _, ok := gameServer.GetRoom(roomID); ok {
+			t.Fatal("部屋が残っている")
+		}
+	})
+
+	t.Run("存在しない部屋には参加できない", func(t *testing.T) {
+		_, client := startTestingServer(t)
+		_, err := client.JoinRoom(ctx, &pb.JoinRoomRequest{RoomId: "unknown", PlayerId: "x"})
+		if status.Code(err) != codes.NotFound {
+			t.Fatal("NotFound のエラーを返さない")
+		}
+	})
+
+	t.Run("部屋のメインループは自動で時間を進める", func(t *testing.T) {
+		gameServer := CreateGameServer(time.Millisecond, createTestingScoreStore(t))
+		defer gameServer.Close()
+		response, _ := gameServer.CreateRoom(ctx, &pb.CreateRoomRequest{})
+		room, _ := gameServer.GetRoom(response.GetRoom().GetRoomId())
+		time.Sleep(time.Millisecond * 50)
+		if room.GetState().GetExecutionTime() == 0 {
+			t.Fatal("時間が進んでいない")
+		}
+	})
+}
+
+func TestGameServer_Spectate_NotTD(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Spectate はフィールド全体と全プレイヤーの状況を送る", func(t *testing.T) {
+		room, client := startTestingRoom(t)
+		client.JoinRoom(ctx, &pb.JoinRoomRequest{RoomId: room.GetID(), PlayerId: "other"})
+		streamCtx, cancel := context.WithCancel(ctx)
+		defer cancel()
+		stream, err := client.Spectate(streamCtx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		stream.Send(&pb.SpectateRequest{RoomId: room.GetID()})
+		response, recvErr := stream.Recv()
+		if recvErr != nil {
+			t.Fatal(recvErr)
+		}
+		field := room.GetState().GetField()
+		if len(response.GetFieldCells()) != field.MeasureRowLength() {
+			t.Fatal("フィールド全体の行数ではない")
+		} else if len(response.GetFieldCells()[0].GetCells()) != field.MeasureColumnLength() {
+			t.Fatal("フィールド全体の列数ではない")
+		} else if len(response.GetPlayers()) != 2 {
+			t.Fatal("全プレイヤーの状況を含まない")
+		} else if response.GetFollowedPlayerId() != testingPlayerID {
+			t.Fatal("最初のプレイヤーを追従していない")
+		} else if response.GetFollowedScreenProps() == nil {
+			t.Fatal("追従しているプレイヤーの画面を含まない")
+		}
+		// 観戦者はヒーローを持たない。
+		if len(room.GetState().GetHeroes()) != 2 {
+			t.Fatal("観戦者が参加者として数えられている")
+		}
+	})
+
+	t.Run("Spectate は追従するプレイヤーを切り替えられる", func(t *testing.T) {
+		room, client := startTestingRoom(t)
+		client.JoinRoom(ctx, &pb.JoinRoomRequest{RoomId: room.GetID(), PlayerId: "other"})
+		streamCtx, cancel := context.WithCancel(ctx)
+		defer cancel()
+		stream, _ := client.Spectate(streamCtx)
+		stream.Send(&pb.SpectateRequest{RoomId: room.GetID()})
+		stream.Recv()
+		stream.Send(&pb.SpectateRequest{FollowPlayerId: "other"})
+		// 切り替えはメインループ毎の送信に反映される。
+		deadline := time.Now().Add(time.Second)
+		for time.Now().Before(deadline) {
+			room.ProceedMainLoop(time.Millisecond)
+			response, recvErr := stream.Recv()
+			if recvErr != nil {
+				t.Fatal(recvErr)
+			}
+			if response.GetFollowedPlayerId() == "other" {
+				return
+			}
+		}
+		t.Fatal("追従するプレイヤーが切り替わらない")
+	})
+
+	t.Run("存在しない部屋は観戦できない", func(t *testing.T) {
+		_, client := startTestingServer(t)
+		stream, _ := client.Spectate(ctx)
+		stream.Send(&pb.SpectateRequest{RoomId: "unknown"})
+		_, err := stream.Recv()
+		if status.Code(err) != codes.NotFound {
+			t.Fatal("NotFound のエラーを返さない")
+		}
+	})
+}
+
+func TestGameServer_Leaderboard_NotTD(t *testing.T) {
+	ctx := context.Background()
+
+	// Start a game in the room and let the time run out.
+	finishGame := func(t *testing.T, room *Room, client pb.GameServiceClient) {
+		client.StartOrRestartGame(ctx, &pb.StartOrRestartGameRequest{RoomId: room.GetID()})
+		room.ProceedMainLoop(time.Second*31)
+		// 時間切れは次のフレームで判定される。
+		room.ProceedMainLoop(time.Millisecond)
+		if !room.GetState().GetGame().IsFinished() {
+			t.Fatal("ゲームが終了していない")
+		}
+	}
+
+	t.Run("SubmitScore は終了したゲームの階数を記録する", func(t *testing.T) {
+		room, client := startTestingRoom(t)
+		finishGame(t, room, client)
+		response, err := client.SubmitScore(ctx, &pb.SubmitScoreRequest{RoomId: room.GetID(), PlayerId: testingPlayerID})
+		if err != nil {
+			t.Fatal(err)
+		} else if response.GetScore().GetPlayerName() != testingPlayerID || response.GetScore().GetFloors() != 1 {
+			t.Fatal("記録が違う")
+		}
+		topScoresResponse, topScoresErr := client.GetTopScores(ctx, &pb.GetTopScoresRequest{})
+		if topScoresErr != nil {
+			t.Fatal(topScoresErr)
+		} else if len(topScoresResponse.GetScores()) != 1 {
+			t.Fatal("保存されていない")
+		}
+	})
+
+	t.Run("終了していないゲームのスコアは記録できない", func(t *testing.T) {
+		room, client := startTestingRoom(t)
+		_, err := client.SubmitScore(ctx, &pb.SubmitScoreRequest{RoomId: room.GetID(), PlayerId: testingPlayerID})
+		if status.Code(err) != codes.FailedPrecondition {
+			t.Fatal("FailedPrecondition のエラーを返さない")
+		}
+	})
+
+	t.Run("同じゲームのスコアは一度だけ記録できる", func(t *testing.T) {
+		room, client := startTestingRoom(t)
+		finishGame(t, room, client)
+		request := &pb.SubmitScoreRequest{RoomId: room.GetID(), PlayerId: testingPlayerID}
+		client.SubmitScore(ctx, request)
+		_, err := client.SubmitScore(ctx, request)
+		if status.Code(err) != codes.AlreadyExists {
+			t.Fatal("AlreadyExists のエラーを返さない")
+		}
+		// 次のゲームでは再び記録できる。
+		finishGame(t, room, client)
+		_, nextErr := client.SubmitScore(ctx, request)
+		if nextErr != nil {
+			t.Fatal(nextErr)
+		}
+	})
+
+	t.Run("画面はゲームの終了を伝える", func(t *testing.T) {
+		room, client := startTestingRoom(t)
+		finishGame(t, room, client)
+		response, _ := client.GetScreen(ctx, &pb.GetScreenRequest{PlayerId: testingPlayerID, RoomId: room.GetID()})
+		if !response.GetScreenProps().GetIsGameFinished() {
+			t.Fatal("終了を伝えていない")
+		}
+	})
+}
@@ -0,0 +1,160 @@
+package solver
+
+import (
+	"github.com/kjirou/gRPC-sample-net-game/models"
+	"github.com/kjirou/gRPC-sample-net-game/utils"
+	"github.com/pkg/errors"
+	"math"
+	"math/rand"
+)
+
+// Metrics of how hard it is to find the way from the entrance to the upstairs.
+type Difficulty struct {
+	// The number of steps of the shortest path.
+	SolutionLength int
+	// The number of passable cells that have only one passable neighbor.
+	DeadEndCount int
+	// The average number of side ways at each step of the shortest path, i.e. wrong choices.
+	BranchingFactor float64
+	// The number of direction changes along the shortest path.
+	TurnCount int
+}
+
+// A single number to compare difficulties. Longer, more winding and more branching routes are harder.
+func (difficulty *Difficulty) CalculateScore() float64 {
+	return float64(difficulty.SolutionLength)*(1+difficulty.BranchingFactor) + float64(difficulty.TurnCount)
+}
+
+func countTurns(path Path) int {
+	count := 0
+	for index := 2; index < len(path); index++ {
+		previousDeltaY := path[index-1].GetY() - path[index-2].GetY()
+		previousDeltaX := path[index-1].GetX() - path[index-2].GetX()
+		deltaY := path[index].GetY() - path[index-1].GetY()
+		deltaX := path[index].GetX() - path[index-1].GetX()
+		if previousDeltaY != deltaY || previousDeltaX != deltaX {
+			count++
+		}
+	}
+	return count
+}
+
+// Measure the difficulty of the floor from the entrance to the upstairs.
+// It returns an error if the floor is unsolvable.
+func MeasureDifficulty(field *models.Field) (*Difficulty, error) {
+	path, ok := SolveFloor(field)
+	if !ok {
+		return nil, errors.New("The upstairs is unreachable from the entrance.")
+	}
+
+	deadEndCount := 0
+	for y := 0; y < field.MeasureRowLength(); y++ {
+		for x := 0; x < field.MeasureColumnLength(); x++ {
+			position := &utils.MatrixPosition{Y: y, X: x}
+			if isPassablePosition(field, position) && len(findPassableNeighbors(field, position)) == 1 {
+				deadEndCount++
+			}
+		}
+	}
+
+	// The start has no way to come from, and the goal needs no choice.
+	sideWayCount := 0
+	for index, position := range path[:len(path)-1] {
+		wayCount := len(findPassableNeighbors(field, position))
+		if index == 0 {
+			sideWayCount += wayCount - 1
+		} else {
+			sideWayCount += wayCount - 2
+		}
+	}
+	branchingFactor := 0.0
+	if path.GetDistance() > 0 {
+		branchingFactor = float64(sideWayCount) / float64(path.GetDistance())
+	}
+
+	return &Difficulty{
+		SolutionLength: path.GetDistance(),
+		DeadEndCount: deadEndCount,
+		BranchingFactor: branchingFactor,
+		TurnCount: countTurns(path),
+	}, nil
+}
+
+// The number of floors that `GenerateFloor` tries at most to hit the target band.
+const DifficultyTargetingAttempts = 20
+
+// Return the minimum and the maximum scores that the floor aims at.
+// It ramps up with the floor number until a limit that most generators can reach on the default field.
+func CalculateTargetDifficultyBand(floorNumber int) (float64, float64) {
+	minimum := 30.0 + 5.0*float64(floorNumber-1)
+	if minimum > 90.0 {
+		minimum = 90.0
+	}
+	return minimum, minimum + 15.0
+}
+
+func generateFloorFromSeed(field *models.Field, game *models.Game, seed int64) error {
+	err := field.ResetMaze(game.GetMazeGenerator(), seed)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	// The same seed places the stairs, items, monsters and doors at the same positions, as well as the maze.
+	random := rand.New(rand.NewSource(seed))
+	placeStairsErr := PlaceStairs(field, game.GetStairsPlacement(), random)
+	if placeStairsErr != nil {
+		return errors.WithStack(placeStairsErr)
+	}
+	placeItemsErr := PlaceItems(field, game.GetItemCount(), random)
+	if placeItemsErr != nil {
+		return errors.WithStack(placeItemsErr)
+	}
+	placeMonstersErr := PlaceMonsters(field, game.GetMonsterCount(), random)
+	if placeMonstersErr != nil {
+		return errors.WithStack(placeMonstersErr)
+	}
+	if !game.HasDoors() {
+		return nil
+	}
+	return PlaceDoorsAndKeys(field, CalculateDoorCount(game.GetFloorNumber()), random)
+}
+
+// Generate the maze, the stairs, items, monsters and doors of the game's current floor. It removes all heroes.
+// If the game targets difficulty, it retries with other seeds until the floor's score is in the target band.
+// When no floor is in the band, the closest one is used. The seeds are derived from the floor's seed,
+// so the same game seed still reproduces the same floors.
+func GenerateFloor(field *models.Field, game *models.Game) error {
+	seed := game.CalculateMazeSeed()
+	if !game.TargetsDifficulty() {
+		return generateFloorFromSeed(field, game, seed)
+	}
+
+	minimum, maximum := CalculateTargetDifficultyBand(game.GetFloorNumber())
+	seedSource := rand.New(rand.NewSource(seed))
+	bestSeed := seed
+	bestGap := math.Inf(1)
+	for attempt := 0; attempt < DifficultyTargetingAttempts; attempt++ {
+		attemptSeed := seed
+		if attempt > 0 {
+			attemptSeed = seedSource.Int63()
+		}
+		err := generateFloorFromSeed(field, game, attemptSeed)
+		if err != nil {
+			return err
+		}
+		difficulty, measureErr := MeasureDifficulty(field)
+		if measureErr != nil {
+			return measureErr
+		}
+		score := difficulty.CalculateScore()
+		if score >= minimum && score <= maximum {
+			return nil
+		}
+		gap := math.Max(minimum-score, score-maximum)
+		if gap < bestGap {
+			bestSeed = attemptSeed
+			bestGap = gap
+		}
+	}
+
+	return generateFloorFromSeed(field, game, bestSeed)
+}
@@ -0,0 +1,215 @@
+package solver
+
+import (
+	"fmt"
+	"github.com/kjirou/gRPC-sample-net-game/models"
+	"github.com/kjirou/gRPC-sample-net-game/utils"
+	"testing"
+)
+
+// A field whose only way is the top row and the right column, i.e. an L-shaped corridor to the upstairs.
+const lShapedFieldText = `
+#####################
+#@..................#
+###################.#
+###################.#
+###################.#
+###################.#
+###################.#
+###################.#
+###################.#
+###################.#
+###################.#
+###################<#
+#####################
+`
+
+func createLShapedField(t *testing.T) *models.Field {
+	field, err := models.ParseFieldText(lShapedFieldText)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return field
+}
+
+func TestMeasureDifficulty_NotTD(t *testing.T) {
+	t.Run("一本道の指標を返す", func(t *testing.T) {
+		field := createLShapedField(t)
+		difficulty, err := MeasureDifficulty(field)
+		if err != nil {
+			t.Fatal(err)
+		} else if difficulty.SolutionLength != 18+10 {
+			t.Fatal("解の長さが違う")
+		} else if difficulty.TurnCount != 1 {
+			t.Fatal("曲がる回数が違う")
+		} else if difficulty.DeadEndCount != 2 {
+			t.Fatal("行き止まりの数が違う")
+		} else if difficulty.BranchingFactor != 0 {
+			t.Fatal("分岐率が違う")
+		}
+	})
+
+	t.Run("脇道は行き止まりと分岐に数える", func(t *testing.T) {
+		field := createLShapedField(t)
+		for _, position := range []*utils.MatrixPosition{&utils.MatrixPosition{Y: 2, X: 5}, &utils.MatrixPosition{Y: 3, X: 5}} {
+			element, _ := field.At(position)
+			element.UpdateObjectKind(models.ObjectKindEmpty)
+		}
+		difficulty, _ := MeasureDifficulty(field)
+		if difficulty.DeadEndCount != 3 {
+			t.Fatal("行き止まりの数が違う")
+		} else if difficulty.BranchingFactor != 1.0/28.0 {
+			t.Fatal("分岐率が違う")
+		} else if difficulty.CalculateScore() <= 28+1 {
+			t.Fatal("一本道より難しくない")
+		}
+	})
+
+	t.Run("解けない階はエラーを返す", func(t *testing.T) {
+		field := createLShapedField(t)
+		placeWalls(field, []*utils.MatrixPosition{&utils.MatrixPosition{Y: 1, X: 10}})
+		_, err := MeasureDifficulty(field)
+		if err == nil {
+			t.Fatal("エラーを返さない")
+		}
+	})
+}
+
+func TestCalculateTargetDifficultyBand_NotTD(t *testing.T) {
+	t.Run("階が上がるほど高くなり、上限で止まる", func(t *testing.T) {
+		previousMinimum, _ := CalculateTargetDifficultyBand(1)
+		for floorNumber := 2; floorNumber <= 100; floorNumber++ {
+			minimum, maximum := CalculateTargetDifficultyBand(floorNumber)
+			if minimum < previousMinimum {
+				t.Fatalf("%d階で下がる", floorNumber)
+			} else if maximum <= minimum {
+				t.Fatalf("%d階の幅がない", floorNumber)
+			}
+			previousMinimum = minimum
+		}
+		first, _ := CalculateTargetDifficultyBand(1)
+		last, _ := CalculateTargetDifficultyBand(100)
+		if last <= first {
+			t.Fatal("高くならない")
+		}
+	})
+}
+
+func TestGenerateFloor_NotTD(t *testing.T) {
+	t.Run("難易度を目標にしないときはその階のシードで生成する", func(t *testing.T) {
+		game := &models.Game{}
+		game.Reset()
+		game.SetSeed(123)
+		a := createTestingField(t)
+		GenerateFloor(a, game)
+		b := createTestingField(t)
+		b.ResetMaze(game.GetMazeGenerator(), game.CalculateMazeSeed())
+		for y := 0; y < a.MeasureRowLength(); y++ {
+			for x := 0; x < a.MeasureColumnLength(); x++ {
+				position := &utils.MatrixPosition{Y: y, X: x}
+				aElement, _ := a.At(position)
+				bElement, _ := b.At(position)
+				if aElement.GetObjectKind() != bElement.GetObjectKind() {
+					t.Fatal("迷路が異なる")
+				}
+			}
+		}
+	})
+
+	for _, placement := range []models.StairsPlacement{models.StairsPlacementFixed, models.StairsPlacementDistant} {
+		t.Run(fmt.Sprintf("%s で各階を目標の難易度の範囲に生成する", placement.GetName()), func(t *testing.T) {
+			for seed := int64(1); seed <= 5; seed++ {
+				game := &models.Game{}
+				game.Reset()
+				game.SetSeed(seed)
+				game.SetStairsPlacement(placement)
+				game.SetTargetsDifficulty(true)
+				for floorNumber := 1; floorNumber <= 6; floorNumber++ {
+					field := createTestingField(t)
+					err := GenerateFloor(field, game)
+					if err != nil {
+						t.Fatal(err)
+					}
+					difficulty, _ := MeasureDifficulty(field)
+					minimum, maximum := CalculateTargetDifficultyBand(floorNumber)
+					if score := difficulty.CalculateScore(); score < minimum || score > maximum {
+						t.Fatalf("シード %d の%d階の難易度 %v が範囲外である", seed, floorNumber, score)
+					}
+					game.IncrementFloorNumber()
+				}
+			}
+		})
+	}
+
+	t.Run("範囲に届かないときは最も近い階を生成する", func(t *testing.T) {
+		game := &models.Game{}
+		game.Reset()
+		game.SetSeed(1)
+		game.SetMazeGeneratorNames([]string{"binary-tree"})
+		game.SetTargetsDifficulty(true)
+		for game.GetFloorNumber() < 30 {
+			game.IncrementFloorNumber()
+		}
+		field := createTestingField(t)
+		GenerateFloor(field, game)
+		difficulty, _ := MeasureDifficulty(field)
+		game.SetTargetsDifficulty(false)
+		untargetedField := createTestingField(t)
+		GenerateFloor(untargetedField, game)
+		untargetedDifficulty, _ := MeasureDifficulty(untargetedField)
+		if difficulty.CalculateScore() < untargetedDifficulty.CalculateScore() {
+			t.Fatal("目標に近づいていない")
+		}
+	})
+
+	t.Run("扉のオプションを有効にしたときだけ扉を置く", func(t *testing.T) {
+		countDoors := func(hasDoors bool) int {
+			doorCount := 0
+			for seed := int64(1); seed <= 5; seed++ {
+				game := &models.Game{}
+				game.Reset()
+				game.SetSeed(seed)
+				game.SetHasDoors(hasDoors)
+				for game.GetFloorNumber() < 7 {
+					game.IncrementFloorNumber()
+				}
+				field := createTestingField(t)
+				err := GenerateFloor(field, game)
+				if err != nil {
+					t.Fatal(err)
+				}
+				for y := 0; y < field.MeasureRowLength(); y++ {
+					for x := 0; x < field.MeasureColumnLength(); x++ {
+						element, _ := field.At(&utils.MatrixPosition{Y: y, X: x})
+						if element.GetFloorObjectKind().IsDoor() {
+							doorCount++
+						}
+					}
+				}
+			}
+			return doorCount
+		}
+		if countDoors(false) != 0 {
+			t.Fatal("無効なのに扉を置いている")
+		} else if countDoors(true) == 0 {
+			t.Fatal("有効なのに扉を置いていない")
+		}
+	})
+
+	t.Run("同じシードからは同じ階を生成する", func(t *testing.T) {
+		generate := func() *Difficulty {
+			game := &models.Game{}
+			game.Reset()
+			game.SetSeed(7)
+			game.SetTargetsDifficulty(true)
+			game.IncrementFloorNumber()
+			field := createTestingField(t)
+			GenerateFloor(field, game)
+			difficulty, _ := MeasureDifficulty(field)
+			return difficulty
+		}
+		if *generate() != *generate() {
+			t.Fatal("異なる階を生成する")
+		}
+	})
+}
@@ -0,0 +1,240 @@
+package solver
+
+import (
+	"github.com/kjirou/gRPC-sample-net-game/models"
+	"github.com/kjirou/gRPC-sample-net-game/utils"
+	"github.com/pkg/errors"
+	"math"
+	"math/rand"
+	"sort"
+)
+
+// The upstairs placed at random is at least this fraction of the farthest distance away from the entrance.
+const MinimumStairsDistanceRatio = 0.5
+
+func findEmptyPositions(field *models.Field) []*utils.MatrixPosition {
+	positions := make([]*utils.MatrixPosition, 0)
+	for y := 0; y < field.MeasureRowLength(); y++ {
+		for x := 0; x < field.MeasureColumnLength(); x++ {
+			position := &utils.MatrixPosition{Y: y, X: x}
+			element, _ := field.At(position)
+			if element.CanBeEntered() {
+				positions = append(positions, position)
+			}
+		}
+	}
+	return positions
+}
+
+// Place the entrance and the upstairs of the floor by the `placement`.
+// It should be called after `Field.ResetMaze` and before heroes are relocated, so that heroes do not occupy cells.
+// The same `random` source always chooses the same positions.
+func PlaceStairs(field *models.Field, placement models.StairsPlacement, random *rand.Rand) error {
+	if placement == models.StairsPlacementFixed {
+		return field.RelocateEntranceAndUpstairs(models.HeroPosition, models.UpstairsPosition)
+	}
+
+	entrance := models.HeroPosition
+	if placement == models.StairsPlacementRandom {
+		emptyPositions := findEmptyPositions(field)
+		if len(emptyPositions) == 0 {
+			return errors.New("There is no space to place the entrance.")
+		}
+		entrance = emptyPositions[random.Intn(len(emptyPositions))]
+	}
+
+	distances := MeasureDistances(field, entrance)
+	farthestDistance := 0
+	upstairs := entrance
+	for _, position := range findEmptyPositions(field) {
+		distance := distances[position.GetY()][position.GetX()]
+		if distance > farthestDistance {
+			farthestDistance = distance
+			upstairs = position
+		}
+	}
+	if farthestDistance == 0 {
+		return errors.New("There is no reachable space to place the upstairs.")
+	}
+
+	if placement != models.StairsPlacementFarthest {
+		minimumDistance := int(math.Ceil(float64(farthestDistance) * MinimumStairsDistanceRatio))
+		candidates := make([]*utils.MatrixPosition, 0)
+		for _, position := range findEmptyPositions(field) {
+			if distances[position.GetY()][position.GetX()] >= minimumDistance {
+				candidates = append(candidates, position)
+			}
+		}
+		upstairs = candidates[random.Intn(len(candidates))]
+	}
+
+	return errors.WithStack(field.RelocateEntranceAndUpstairs(entrance, upstairs))
+}
+
+// Monsters are placed at least this number of steps away from the entrance, so that heroes are not caught at once.
+const MinimumMonsterDistance = models.MonsterChaseRange + 1
+
+// Place the `count` monsters at random cells that are reachable and far enough from the entrance.
+// They are not placed on the upstairs. If there are not enough cells, it places as many as possible.
+// It should be called after `PlaceStairs`. The same `random` source always chooses the same positions.
+func PlaceMonsters(field *models.Field, count int, random *rand.Rand) error {
+	if count == 0 {
+		return nil
+	}
+	distances := MeasureDistances(field, field.GetEntrancePosition())
+	upstairs := field.GetUpstairsPosition()
+	candidates := make([]*utils.MatrixPosition, 0)
+	for _, position := range findEmptyPositions(field) {
+		isUpstairs := position.GetY() == upstairs.GetY() && position.GetX() == upstairs.GetX()
+		if !isUpstairs && distances[position.GetY()][position.GetX()] >= MinimumMonsterDistance {
+			candidates = append(candidates, position)
+		}
+	}
+	random.Shuffle(len(candidates), func(i, j int) {
+		candidates[i], candidates[j] = candidates[j], candidates[i]
+	})
+	for index, position := range candidates {
+		if index >= count {
+			break
+		}
+		// Each monster's random source is derived from the floor's one.
+		err := field.PlaceMonster(position, models.CreateMonster(rand.New(rand.NewSource(random.Int63()))))
+		if err != nil {
+			return errors.WithStack(err)
+		}
+	}
+	return nil
+}
+
+// Place the `count` items at random reachable cells. Each item is one of `models.GetItemKinds()` at random.
+// They are not placed on the entrance, the upstairs, or cells that already have floor objects.
+// It should be called after `PlaceStairs`. The same `random` source always chooses the same positions and kinds.
+func PlaceItems(field *models.Field, count int, random *rand.Rand) error {
+	if count == 0 {
+		return nil
+	}
+	distances := MeasureDistances(field, field.GetEntrancePosition())
+	candidates := make([]*utils.MatrixPosition, 0)
+	for _, position := range findEmptyPositions(field) {
+		element, _ := field.At(position)
+		if element.GetFloorObjectKind() == models.ObjectKindEmpty && distances[position.GetY()][position.GetX()] > 0 {
+			candidates = append(candidates, position)
+		}
+	}
+	random.Shuffle(len(candidates), func(i, j int) {
+		candidates[i], candidates[j] = candidates[j], candidates[i]
+	})
+	itemKinds := models.GetItemKinds()
+	for index, position := range candidates {
+		if index >= count {
+			break
+		}
+		element, _ := field.At(position)
+		element.UpdateFloorObjectKind(itemKinds[random.Intn(len(itemKinds))])
+	}
+	return nil
+}
+
+// Doors appear from this floor, so that early floors are plain mazes.
+const DoorsFirstFloorNumber = 3
+
+// One more door is added every this number of floors, up to the number of door kinds.
+const FloorsPerDoor = 2
+
+// Return the number of doors on the floor.
+func CalculateDoorCount(floorNumber int) int {
+	if floorNumber < DoorsFirstFloorNumber {
+		return 0
+	}
+	count := (floorNumber-DoorsFirstFloorNumber)/FloorsPerDoor + 1
+	if count > len(models.GetDoorKinds()) {
+		count = len(models.GetDoorKinds())
+	}
+	return count
+}
+
+// Return positions on the path where a door would cut the upstairs off from the entrance.
+func findChokepoints(field *models.Field, path Path) []*utils.MatrixPosition {
+	entrance := field.GetEntrancePosition()
+	upstairs := field.GetUpstairsPosition()
+	chokepoints := make([]*utils.MatrixPosition, 0)
+	for _, position := range path {
+		element, _ := field.At(position)
+		if !element.CanBeEntered() || element.GetFloorObjectKind() != models.ObjectKindEmpty ||
+			*position == *entrance {
+			continue
+		}
+		// Block it for a moment.
+		element.UpdateFloorObjectKind(models.GetDoorKinds()[0])
+		distances := MeasureDistances(field, entrance)
+		element.UpdateFloorObjectKind(models.ObjectKindEmpty)
+		if distances[upstairs.GetY()][upstairs.GetX()] == -1 {
+			chokepoints = append(chokepoints, position)
+		}
+	}
+	return chokepoints
+}
+
+// Place the `count` doors on the route from the entrance to the upstairs, and a key of each door.
+// Doors are placed at chokepoints, so that heroes can not reach the upstairs without opening them.
+// Each key is placed where heroes can reach with the keys of the doors before it, so that the floor is always solvable.
+// If there are not enough chokepoints or spaces for keys, it places as many as possible.
+// It should be called after other objects are placed, because they are placed only on the entrance's side of doors.
+// The same `random` source always chooses the same positions.
+func PlaceDoorsAndKeys(field *models.Field, count int, random *rand.Rand) error {
+	if count == 0 {
+		return nil
+	}
+	doorKinds := models.GetDoorKinds()
+	if count > len(doorKinds) {
+		return errors.Errorf("The number of doors must be %d or less, but it is %d.", len(doorKinds), count)
+	}
+	path, ok := SolveFloor(field)
+	if !ok {
+		return errors.New("The upstairs is unreachable from the entrance.")
+	}
+
+	// Choose chokepoints at random, and color them in the order along the route.
+	chokepoints := findChokepoints(field, path)
+	indices := random.Perm(len(chokepoints))
+	if len(indices) > count {
+		indices = indices[:count]
+	}
+	sort.Ints(indices)
+	doorPositions := make([]*utils.MatrixPosition, len(indices))
+	for order, index := range indices {
+		doorPositions[order] = chokepoints[index]
+		element, _ := field.At(chokepoints[index])
+		element.UpdateFloorObjectKind(doorKinds[order])
+	}
+
+	keyKinds := make([]models.ObjectKind, 0, len(doorPositions))
+	for order, doorPosition := range doorPositions {
+		distances := MeasureDistancesWithKeys(field, field.GetEntrancePosition(), keyKinds)
+		candidates := make([]*utils.MatrixPosition, 0)
+		for _, position := range findEmptyPositions(field) {
+			element, _ := field.At(position)
+			if element.GetFloorObjectKind() == models.ObjectKindEmpty && distances[position.GetY()][position.GetX()] > 0 {
+				candidates = append(candidates, position)
+			}
+		}
+		if len(candidates) == 0 {
+			// This door and the following doors can not have keys.
+			for _, position := range doorPositions[order:] {
+				element, _ := field.At(position)
+				element.UpdateFloorObjectKind(models.ObjectKindEmpty)
+			}
+			break
+		}
+		doorElement, _ := field.At(doorPosition)
+		keyKind, _ := doorElement.GetFloorObjectKind().FindKeyKind()
+		keyElement, _ := field.At(candidates[random.Intn(len(candidates))])
+		keyElement.UpdateFloorObjectKind(keyKind)
+		keyKinds = append(keyKinds, keyKind)
+	}
+
+	if _, solved := SolveFloor(field); !solved {
+		return errors.New("The doors make the floor unsolvable.")
+	}
+	return nil
+}
@@ -0,0 +1,253 @@
+package solver
+
+import (
+	"fmt"
+	"github.com/kjirou/gRPC-sample-net-game/models"
+	"github.com/kjirou/gRPC-sample-net-game/utils"
+	"math/rand"
+	"testing"
+)
+
+func createTestingMazeField(t *testing.T, seed int64) *models.Field {
+	field := createTestingField(t)
+	err := field.ResetMaze(&utils.ClusteringMazeGenerator{}, seed)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return field
+}
+
+func TestPlaceStairs_NotTD(t *testing.T) {
+	t.Run("fixed は既定の位置に置く", func(t *testing.T) {
+		field := createTestingMazeField(t, 1)
+		PlaceStairs(field, models.StairsPlacementFixed, rand.New(rand.NewSource(1)))
+		if field.GetEntrancePosition() != models.HeroPosition || field.GetUpstairsPosition() != models.UpstairsPosition {
+			t.Fatal("既定の位置ではない")
+		}
+	})
+
+	t.Run("farthest は入口から最も遠い位置に上り階段を置く", func(t *testing.T) {
+		for seed := int64(1); seed <= 10; seed++ {
+			field := createTestingMazeField(t, seed)
+			err := PlaceStairs(field, models.StairsPlacementFarthest, rand.New(rand.NewSource(seed)))
+			if err != nil {
+				t.Fatal(err)
+			}
+			distances := MeasureDistances(field, models.HeroPosition)
+			upstairs := field.GetUpstairsPosition()
+			for _, row := range distances {
+				for _, distance := range row {
+					if distance > distances[upstairs.GetY()][upstairs.GetX()] {
+						t.Fatalf("シード %d でより遠い位置がある", seed)
+					}
+				}
+			}
+			element, _ := field.At(upstairs)
+			if element.GetFloorObjectKind() != models.ObjectKindUpstairs {
+				t.Fatal("上り階段が置かれていない")
+			}
+		}
+	})
+
+	for _, placement := range []models.StairsPlacement{models.StairsPlacementDistant, models.StairsPlacementRandom} {
+		t.Run(fmt.Sprintf("%s は入口から十分に離れた位置に上り階段を置く", placement.GetName()), func(t *testing.T) {
+			movedEntrance := false
+			for seed := int64(1); seed <= 20; seed++ {
+				field := createTestingMazeField(t, seed)
+				err := PlaceStairs(field, placement, rand.New(rand.NewSource(seed)))
+				if err != nil {
+					t.Fatal(err)
+				}
+				entrance := field.GetEntrancePosition()
+				if entrance.GetY() != models.HeroPosition.GetY() || entrance.GetX() != models.HeroPosition.GetX() {
+					movedEntrance = true
+				}
+				distances := MeasureDistances(field, entrance)
+				farthestDistance := 0
+				for _, row := range distances {
+					for _, distance := range row {
+						if distance > farthestDistance {
+							farthestDistance = distance
+						}
+					}
+				}
+				path, ok := SolveFloor(field)
+				if !ok {
+					t.Fatalf("シード %d の階を解けない", seed)
+				} else if float64(path.GetDistance()) < float64(farthestDistance)*MinimumStairsDistanceRatio {
+					t.Fatalf("シード %d の上り階段が近すぎる", seed)
+				}
+			}
+			if movedEntrance != (placement == models.StairsPlacementRandom) {
+				t.Fatal("入口の移動が違う")
+			}
+		})
+	}
+
+	t.Run("同じ乱数からは同じ位置に置く", func(t *testing.T) {
+		a := createTestingMazeField(t, 1)
+		PlaceStairs(a, models.StairsPlacementRandom, rand.New(rand.NewSource(123)))
+		b := createTestingMazeField(t, 1)
+		PlaceStairs(b, models.StairsPlacementRandom, rand.New(rand.NewSource(123)))
+		if *a.GetEntrancePosition() != *b.GetEntrancePosition() || *a.GetUpstairsPosition() != *b.GetUpstairsPosition() {
+			t.Fatal("位置が異なる")
+		}
+	})
+
+	t.Run("上り階段を置ける位置がないときはエラーを返す", func(t *testing.T) {
+		field := createTestingField(t)
+		walls := make([]*utils.MatrixPosition, 0)
+		for y := 1; y < field.MeasureRowLength()-1; y++ {
+			for x := 1; x < field.MeasureColumnLength()-1; x++ {
+				if y != 1 || x != 1 {
+					walls = append(walls, &utils.MatrixPosition{Y: y, X: x})
+				}
+			}
+		}
+		placeWalls(field, walls)
+		if PlaceStairs(field, models.StairsPlacementFarthest, rand.New(rand.NewSource(1))) == nil {
+			t.Fatal("エラーを返さない")
+		}
+	})
+}
+
+func TestPlaceMonsters_NotTD(t *testing.T) {
+	t.Run("入口から十分に離れた上り階段以外の位置に置く", func(t *testing.T) {
+		for seed := int64(1); seed <= 10; seed++ {
+			field := createTestingMazeField(t, seed)
+			PlaceStairs(field, models.StairsPlacementFixed, rand.New(rand.NewSource(seed)))
+			err := PlaceMonsters(field, 3, rand.New(rand.NewSource(seed)))
+			if err != nil {
+				t.Fatal(err)
+			}
+			elements := field.GetElementsOfMonsters()
+			if len(elements) != 3 {
+				t.Fatalf("シード %d で置いた数が違う", seed)
+			}
+			distances := MeasureDistances(field, field.GetEntrancePosition())
+			for _, element := range elements {
+				position := element.GetPosition()
+				if distances[position.GetY()][position.GetX()] < MinimumMonsterDistance {
+					t.Fatalf("シード %d で入口に近い", seed)
+				} else if element.GetFloorObjectKind() == models.ObjectKindUpstairs {
+					t.Fatalf("シード %d で上り階段に置いている", seed)
+				}
+			}
+		}
+	})
+
+	t.Run("置ける位置が足りないときは置けるだけ置く", func(t *testing.T) {
+		field, _ := models.ParseFieldText("#############\n#@.........<#\n#############")
+		err := PlaceMonsters(field, 5, rand.New(rand.NewSource(1)))
+		if err != nil {
+			t.Fatal(err)
+		} else if len(field.GetElementsOfMonsters()) != 1 {
+			t.Fatal("置いた数が違う")
+		}
+	})
+}
+
+func TestPlaceItems_NotTD(t *testing.T) {
+	t.Run("入口と上り階段以外の到達できる位置に置く", func(t *testing.T) {
+		for seed := int64(1); seed <= 10; seed++ {
+			field := createTestingMazeField(t, seed)
+			PlaceStairs(field, models.StairsPlacementFixed, rand.New(rand.NewSource(seed)))
+			err := PlaceItems(field, 3, rand.New(rand.NewSource(seed)))
+			if err != nil {
+				t.Fatal(err)
+			}
+			distances := MeasureDistances(field, field.GetEntrancePosition())
+			count := 0
+			for y := 0; y < field.MeasureRowLength(); y++ {
+				for x := 0; x < field.MeasureColumnLength(); x++ {
+					element, _ := field.At(&utils.MatrixPosition{Y: y, X: x})
+					if !element.GetFloorObjectKind().IsItem() {
+						continue
+					}
+					count++
+					if distances[y][x] <= 0 {
+						t.Fatalf("シード %d で到達できない位置か入口に置いている", seed)
+					}
+				}
+			}
+			if count != 3 {
+				t.Fatalf("シード %d で置いた数が違う", seed)
+			} else if _, ok := SolveFloor(field); !ok {
+				t.Fatalf("シード %d で上り階段を上書きしている", seed)
+			}
+		}
+	})
+}
+
+func TestCalculateDoorCount_NotTD(t *testing.T) {
+	t.Run("扉は途中の階から現れ、扉の種類の数まで増える", func(t *testing.T) {
+		testCases := []struct {
+			floorNumber int
+			want int
+		}{
+			{floorNumber: 1, want: 0},
+			{floorNumber: DoorsFirstFloorNumber, want: 1},
+			{floorNumber: DoorsFirstFloorNumber + FloorsPerDoor, want: 2},
+			{floorNumber: 100, want: len(models.GetDoorKinds())},
+		}
+		for _, testCase := range testCases {
+			if got := CalculateDoorCount(testCase.floorNumber); got != testCase.want {
+				t.Fatalf("%d 階の扉の数が %d ではなく %d になる", testCase.floorNumber, testCase.want, got)
+			}
+		}
+	})
+}
+
+func TestPlaceDoorsAndKeys_NotTD(t *testing.T) {
+	t.Run("扉を開けなければ上り階段へ行けず、鍵を順に拾えば必ず解ける", func(t *testing.T) {
+		placedAllDoors := false
+		for _, name := range utils.GetMazeGeneratorNames() {
+			generator, _ := utils.FindMazeGenerator(name)
+			for seed := int64(1); seed <= 10; seed++ {
+				field := createTestingField(t)
+				field.ResetMaze(generator, seed)
+				PlaceStairs(field, models.StairsPlacementFarthest, rand.New(rand.NewSource(seed)))
+				err := PlaceDoorsAndKeys(field, 2, rand.New(rand.NewSource(seed)))
+				if err != nil {
+					t.Fatalf("%s のシード %d: %+v", name, seed, err)
+				}
+				doorCount := 0
+				keyCount := 0
+				for y := 0; y < field.MeasureRowLength(); y++ {
+					for x := 0; x < field.MeasureColumnLength(); x++ {
+						element, _ := field.At(&utils.MatrixPosition{Y: y, X: x})
+						if element.GetFloorObjectKind().IsDoor() {
+							doorCount++
+						} else if element.GetFloorObjectKind().IsKey() {
+							keyCount++
+						}
+					}
+				}
+				if doorCount != keyCount {
+					t.Fatalf("%s のシード %d で扉と鍵の数が違う", name, seed)
+				} else if doorCount == 2 {
+					placedAllDoors = true
+				}
+				if _, ok := SolveFloor(field); !ok {
+					t.Fatalf("%s のシード %d の階を解けない", name, seed)
+				}
+				upstairs := field.GetUpstairsPosition()
+				distances := MeasureDistances(field, field.GetEntrancePosition())
+				if doorCount > 0 && distances[upstairs.GetY()][upstairs.GetX()] != -1 {
+					t.Fatalf("%s のシード %d で扉を通らずに上り階段へ行ける", name, seed)
+				}
+			}
+		}
+		if !placedAllDoors {
+			t.Fatal("指定した数の扉を置けた階がない")
+		}
+	})
+
+	t.Run("扉の種類より多くは置けない", func(t *testing.T) {
+		field := createTestingMazeField(t, 1)
+		PlaceStairs(field, models.StairsPlacementFixed, rand.New(rand.NewSource(1)))
+		if PlaceDoorsAndKeys(field, len(models.GetDoorKinds())+1, rand.New(rand.NewSource(1))) == nil {
+			t.Fatal("エラーを返さない")
+		}
+	})
+}
@@ -0,0 +1,304 @@
+package solver
+
+//
+// The "solver" package finds routes on fields, e.g. for hints, bots and checks of generated floors.
+//
+
+import (
+	"container/heap"
+	"github.com/kjirou/gRPC-sample-net-game/models"
+	"github.com/kjirou/gRPC-sample-net-game/utils"
+)
+
+// Positions from the start to the goal, both inclusive.
+type Path []*utils.MatrixPosition
+
+// The number of steps.
+func (path Path) GetDistance() int {
+	return len(path) - 1
+}
+
+// Return passable positions next to the position in the order of up, right, down and left.
+func findPassableNeighbors(field *models.Field, position *utils.MatrixPosition) []*utils.MatrixPosition {
+	return findNeighborsWithKeys(field, position, keySet(0))
+}
+
+func isPassablePosition(field *models.Field, position *utils.MatrixPosition) bool {
+	element, ok := field.At(position)
+	return ok && element.IsPassable()
+}
+
+// Follow `previous` links back from the goal.
+func tracePath(previous [][]*utils.MatrixPosition, from *utils.MatrixPosition, to *utils.MatrixPosition) Path {
+	reversed := Path{to}
+	for current := to; current.GetY() != from.GetY() || current.GetX() != from.GetX(); {
+		current = previous[current.GetY()][current.GetX()]
+		reversed = append(reversed, current)
+	}
+	path := make(Path, len(reversed))
+	for index, position := range reversed {
+		path[len(reversed)-1-index] = position
+	}
+	return path
+}
+
+func createPositionMatrix(field *models.Field) [][]*utils.MatrixPosition {
+	matrix := make([][]*utils.MatrixPosition, field.MeasureRowLength())
+	for y := range matrix {
+		matrix[y] = make([]*utils.MatrixPosition, field.MeasureColumnLength())
+	}
+	return matrix
+}
+
+// Measure the number of steps from the position to each position with breadth-first search.
+// Unreachable positions are -1.
+func MeasureDistances(field *models.Field, from *utils.MatrixPosition) [][]int {
+	return MeasureDistancesWithKeys(field, from, nil)
+}
+
+// Find one of the shortest paths with breadth-first search.
+// The second value is false if the goal is unreachable or the positions are out of the field.
+func FindPath(field *models.Field, from *utils.MatrixPosition, to *utils.MatrixPosition) (Path, bool) {
+	if !isPassablePosition(field, from) || !isPassablePosition(field, to) {
+		return nil, false
+	}
+	previous := createPositionMatrix(field)
+	previous[from.GetY()][from.GetX()] = from
+	queue := []*utils.MatrixPosition{from}
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+		if current.GetY() == to.GetY() && current.GetX() == to.GetX() {
+			return tracePath(previous, from, current), true
+		}
+		for _, neighbor := range findPassableNeighbors(field, current) {
+			if previous[neighbor.GetY()][neighbor.GetX()] == nil {
+				previous[neighbor.GetY()][neighbor.GetX()] = current
+				queue = append(queue, neighbor)
+			}
+		}
+	}
+	return nil, false
+}
+
+type aStarNode struct {
+	position *utils.MatrixPosition
+	// The number of steps from the start.
+	cost int
+	// The cost plus the estimated number of steps to the goal.
+	score int
+}
+
+// A min-heap of nodes by the score for `container/heap`.
+type aStarQueue []*aStarNode
+
+func (queue aStarQueue) Len() int {
+	return len(queue)
+}
+
+func (queue aStarQueue) Less(a, b int) bool {
+	return queue[a].score < queue[b].score
+}
+
+func (queue aStarQueue) Swap(a, b int) {
+	queue[a], queue[b] = queue[b], queue[a]
+}
+
+func (queue *aStarQueue) Push(node interface{}) {
+	*queue = append(*queue, node.(*aStarNode))
+}
+
+func (queue *aStarQueue) Pop() interface{} {
+	old := *queue
+	node := old[len(old)-1]
+	*queue = old[:len(old)-1]
+	return node
+}
+
+func measureManhattanDistance(a *utils.MatrixPosition, b *utils.MatrixPosition) int {
+	deltaY := a.GetY() - b.GetY()
+	if deltaY < 0 {
+		deltaY = -deltaY
+	}
+	deltaX := a.GetX() - b.GetX()
+	if deltaX < 0 {
+		deltaX = -deltaX
+	}
+	return deltaY + deltaX
+}
+
+// Find one of the shortest paths with A* search.
+// It returns the same distance as `FindPath`, but visits fewer positions on open fields.
+func FindPathWithAStar(field *models.Field, from *utils.MatrixPosition, to *utils.MatrixPosition) (Path, bool) {
+	if !isPassablePosition(field, from) || !isPassablePosition(field, to) {
+		return nil, false
+	}
+	previous := createPositionMatrix(field)
+	costs := make([][]int, field.MeasureRowLength())
+	for y := range costs {
+		costs[y] = make([]int, field.MeasureColumnLength())
+		for x := range costs[y] {
+			costs[y][x] = -1
+		}
+	}
+	previous[from.GetY()][from.GetX()] = from
+	costs[from.GetY()][from.GetX()] = 0
+	queue := &aStarQueue{{position: from, cost: 0, score: measureManhattanDistance(from, to)}}
+	for queue.Len() > 0 {
+		node := heap.Pop(queue).(*aStarNode)
+		current := node.position
+		// Skip stale nodes that have been replaced by cheaper ones.
+		if node.cost > costs[current.GetY()][current.GetX()] {
+			continue
+		}
+		if current.GetY() == to.GetY() && current.GetX() == to.GetX() {
+			return tracePath(previous, from, current), true
+		}
+		for _, neighbor := range findPassableNeighbors(field, current) {
+			cost := node.cost + 1
+			knownCost := costs[neighbor.GetY()][neighbor.GetX()]
+			if knownCost == -1 || cost < knownCost {
+				costs[neighbor.GetY()][neighbor.GetX()] = cost
+				previous[neighbor.GetY()][neighbor.GetX()] = current
+				heap.Push(queue, &aStarNode{
+					position: neighbor,
+					cost: cost,
+					score: cost + measureManhattanDistance(neighbor, to),
+				})
+			}
+		}
+	}
+	return nil, false
+}
+
+// Find the shortest path from the entrance to the upstairs of the floor.
+// Keys on the floor are picked up on the way, so floors with doors are also solved.
+func SolveFloor(field *models.Field) (Path, bool) {
+	return FindPathWithKeys(field, field.GetEntrancePosition(), field.GetUpstairsPosition(), nil)
+}
+
+// Colors of keys that are carried during a search. Each bit is a `models.ObjectColor`.
+type keySet uint
+
+func createKeySet(keyKinds []models.ObjectKind) keySet {
+	keys := keySet(0)
+	for _, keyKind := range keyKinds {
+		keys = keys.add(keyKind)
+	}
+	return keys
+}
+
+func (keys keySet) add(keyKind models.ObjectKind) keySet {
+	return keys | 1<<uint(keyKind.GetDefinition().Color)
+}
+
+func (keys keySet) opens(doorKind models.ObjectKind) bool {
+	return doorKind.IsDoor() && keys&(1<<uint(doorKind.GetDefinition().Color)) != 0
+}
+
+// Whether the position can be stepped on with the keys. Doors are passable only with their keys.
+func canStepOnWithKeys(field *models.Field, position *utils.MatrixPosition, keys keySet) bool {
+	element, ok := field.At(position)
+	if !ok {
+		return false
+	}
+	return element.IsPassable() ||
+		element.GetObjectKind().IsPassable() && keys.opens(element.GetFloorObjectKind())
+}
+
+// Return positions next to the position that can be stepped on with the keys, in the same order as `findPassableNeighbors`.
+func findNeighborsWithKeys(field *models.Field, position *utils.MatrixPosition, keys keySet) []*utils.MatrixPosition {
+	y := position.GetY()
+	x := position.GetX()
+	candidates := []*utils.MatrixPosition{
+		&utils.MatrixPosition{Y: y - 1, X: x},
+		&utils.MatrixPosition{Y: y, X: x + 1},
+		&utils.MatrixPosition{Y: y + 1, X: x},
+		&utils.MatrixPosition{Y: y, X: x - 1},
+	}
+	neighbors := make([]*utils.MatrixPosition, 0, 4)
+	for _, candidate := range candidates {
+		if canStepOnWithKeys(field, candidate, keys) {
+			neighbors = append(neighbors, candidate)
+		}
+	}
+	return neighbors
+}
+
+// Measure the number of steps like `MeasureDistances`, but doors of the `keyKinds` are passable.
+// Keys on the way are not picked up.
+func MeasureDistancesWithKeys(field *models.Field, from *utils.MatrixPosition, keyKinds []models.ObjectKind) [][]int {
+	keys := createKeySet(keyKinds)
+	distances := make([][]int, field.MeasureRowLength())
+	for y := range distances {
+		distances[y] = make([]int, field.MeasureColumnLength())
+		for x := range distances[y] {
+			distances[y][x] = -1
+		}
+	}
+	if !canStepOnWithKeys(field, from, keys) {
+		return distances
+	}
+	distances[from.GetY()][from.GetX()] = 0
+	queue := []*utils.MatrixPosition{from}
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+		for _, neighbor := range findNeighborsWithKeys(field, current, keys) {
+			if distances[neighbor.GetY()][neighbor.GetX()] == -1 {
+				distances[neighbor.GetY()][neighbor.GetX()] = distances[current.GetY()][current.GetX()] + 1
+				queue = append(queue, neighbor)
+			}
+		}
+	}
+	return distances
+}
+
+// A position with the keys that have been picked up until then.
+type keySearchNode struct {
+	y int
+	x int
+	keys keySet
+}
+
+// Find one of the shortest paths with breadth-first search, starting with the `keyKinds`.
+// Keys on the way are picked up, and then their doors are passable. So the path may take a detour to keys.
+func FindPathWithKeys(
+	field *models.Field, from *utils.MatrixPosition, to *utils.MatrixPosition, keyKinds []models.ObjectKind) (Path, bool) {
+	keys := createKeySet(keyKinds)
+	if !canStepOnWithKeys(field, from, keys) || !isPassablePosition(field, to) {
+		return nil, false
+	}
+	start := keySearchNode{y: from.GetY(), x: from.GetX(), keys: keys}
+	previous := map[keySearchNode]keySearchNode{start: start}
+	queue := []keySearchNode{start}
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+		if current.y == to.GetY() && current.x == to.GetX() {
+			reversed := Path{&utils.MatrixPosition{Y: current.y, X: current.x}}
+			for node := current; node != start; {
+				node = previous[node]
+				reversed = append(reversed, &utils.MatrixPosition{Y: node.y, X: node.x})
+			}
+			path := make(Path, len(reversed))
+			for index, position := range reversed {
+				path[len(reversed)-1-index] = position
+			}
+			return path, true
+		}
+		for _, neighbor := range findNeighborsWithKeys(field, &utils.MatrixPosition{Y: current.y, X: current.x}, current.keys) {
+			neighborKeys := current.keys
+			element, _ := field.At(neighbor)
+			if element.GetFloorObjectKind().IsKey() {
+				neighborKeys = neighborKeys.add(element.GetFloorObjectKind())
+			}
+			node := keySearchNode{y: neighbor.GetY(), x: neighbor.GetX(), keys: neighborKeys}
+			if _, ok := previous[node]; !ok {
+				previous[node] = current
+				queue = append(queue, node)
+			}
+		}
+	}
+	return nil, false
+}
@@ -0,0 +1,196 @@
+package solver
+
+import (
+	"fmt"
+	"github.com/kjirou/gRPC-sample-net-game/models"
+	"github.com/kjirou/gRPC-sample-net-game/utils"
+	"math/rand"
+	"testing"
+)
+
+// Create a 13*21 field surrounded by walls.
+func createTestingField(t *testing.T) *models.Field {
+	state := models.CreateState()
+	err := state.SetWelcomeData()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return state.GetField()
+}
+
+func placeWalls(field *models.Field, positions []*utils.MatrixPosition) {
+	for _, position := range positions {
+		element, _ := field.At(position)
+		element.UpdateObjectKind(models.ObjectKindWall)
+	}
+}
+
+func assertContinuousPath(t *testing.T, field *models.Field, path Path) {
+	for index, position := range path {
+		element, _ := field.At(position)
+		if !element.IsPassable() {
+			t.Fatalf("Y=%d,X=%d は通れない", position.GetY(), position.GetX())
+		}
+		if index > 0 && measureManhattanDistance(path[index-1], position) != 1 {
+			t.Fatalf("Y=%d,X=%d が前の位置と隣接していない", position.GetY(), position.GetX())
+		}
+	}
+}
+
+func TestFindPath_NotTD(t *testing.T) {
+	finders := map[string]func(*models.Field, *utils.MatrixPosition, *utils.MatrixPosition) (Path, bool){
+		"幅優先探索": FindPath,
+		"A*": FindPathWithAStar,
+	}
+	for name, find := range finders {
+		t.Run(fmt.Sprintf("%s で", name), func(t *testing.T) {
+			t.Run("障害物がなければマンハッタン距離で到達する", func(t *testing.T) {
+				field := createTestingField(t)
+				from := &utils.MatrixPosition{Y: 1, X: 1}
+				to := &utils.MatrixPosition{Y: 5, X: 9}
+				path, ok := find(field, from, to)
+				if !ok {
+					t.Fatal("到達できない")
+				} else if path.GetDistance() != 12 {
+					t.Fatal("距離が違う")
+				} else if path[0] != from || path[len(path)-1].GetY() != 5 || path[len(path)-1].GetX() != 9 {
+					t.Fatal("始点か終点が違う")
+				}
+				assertContinuousPath(t, field, path)
+			})
+
+			t.Run("壁を迂回する", func(t *testing.T) {
+				field := createTestingField(t)
+				// X=3 の列を Y=1-10 まで塞ぎ、Y=11 だけ空ける。
+				walls := make([]*utils.MatrixPosition, 0)
+				for y := 1; y <= 10; y++ {
+					walls = append(walls, &utils.MatrixPosition{Y: y, X: 3})
+				}
+				placeWalls(field, walls)
+				path, ok := find(field, &utils.MatrixPosition{Y: 1, X: 1}, &utils.MatrixPosition{Y: 1, X: 5})
+				if !ok {
+					t.Fatal("到達できない")
+				} else if path.GetDistance() != 10+4+10 {
+					t.Fatal("最短ではない")
+				}
+				assertContinuousPath(t, field, path)
+			})
+
+			t.Run("ヒーローは通り抜けられる", func(t *testing.T) {
+				state := models.CreateState()
+				state.SetWelcomeData()
+				state.AddHero("a")
+				path, ok := find(state.GetField(), models.HeroPosition, &utils.MatrixPosition{Y: 1, X: 3})
+				if !ok || path.GetDistance() != 2 {
+					t.Fatal("ヒーローの位置から探索できない")
+				}
+			})
+
+			t.Run("到達できないときは false を返す", func(t *testing.T) {
+				field := createTestingField(t)
+				placeWalls(field, []*utils.MatrixPosition{
+					&utils.MatrixPosition{Y: 1, X: 2},
+					&utils.MatrixPosition{Y: 2, X: 1},
+				})
+				if _, ok := find(field, &utils.MatrixPosition{Y: 1, X: 1}, &utils.MatrixPosition{Y: 5, X: 5}); ok {
+					t.Fatal("囲まれた位置から到達できる")
+				}
+				if _, ok := find(field, &utils.MatrixPosition{Y: 5, X: 5}, &utils.MatrixPosition{Y: 0, X: 0}); ok {
+					t.Fatal("壁に到達できる")
+				}
+				if _, ok := find(field, &utils.MatrixPosition{Y: 5, X: 5}, &utils.MatrixPosition{Y: 99, X: 5}); ok {
+					t.Fatal("フィールド外に到達できる")
+				}
+			})
+
+			t.Run("始点と終点が同じときは距離0を返す", func(t *testing.T) {
+				field := createTestingField(t)
+				path, ok := find(field, &utils.MatrixPosition{Y: 3, X: 3}, &utils.MatrixPosition{Y: 3, X: 3})
+				if !ok || path.GetDistance() != 0 {
+					t.Fatal("距離0ではない")
+				}
+			})
+		})
+	}
+}
+
+func TestMeasureDistances_NotTD(t *testing.T) {
+	t.Run("各位置への歩数を返し、到達できない位置は-1になる", func(t *testing.T) {
+		field := createTestingField(t)
+		distances := MeasureDistances(field, &utils.MatrixPosition{Y: 1, X: 1})
+		if distances[1][1] != 0 || distances[2][3] != 3 || distances[11][19] != 28 {
+			t.Fatal("歩数が違う")
+		} else if distances[0][0] != -1 {
+			t.Fatal("壁が-1ではない")
+		}
+	})
+}
+
+func TestSolveFloor_NotTD(t *testing.T) {
+	for _, name := range utils.GetMazeGeneratorNames() {
+		for _, loopDensity := range []float64{0, 0.3} {
+			t.Run(fmt.Sprintf("%s で密度%vの全ての階を解ける", name, loopDensity), func(t *testing.T) {
+				game := &models.Game{}
+				game.Reset()
+				game.SetMazeGeneratorNames([]string{name})
+				game.SetLoopDensity(loopDensity)
+				for seed := int64(1); seed <= 30; seed++ {
+					field := createTestingField(t)
+					game.SetSeed(seed)
+					err := field.ResetMaze(game.GetMazeGenerator(), game.CalculateMazeSeed())
+					if err != nil {
+						t.Fatal(err)
+					}
+					// 配置方法を順に試す。
+					placement := models.StairsPlacement(seed % 4)
+					placeErr := PlaceStairs(field, placement, rand.New(rand.NewSource(seed)))
+					if placeErr != nil {
+						t.Fatal(placeErr)
+					}
+					path, ok := SolveFloor(field)
+					if !ok {
+						t.Fatalf("シード %d の階を解けない", seed)
+					}
+					assertContinuousPath(t, field, path)
+					// 扉のない階なので、A* と同じ距離になる。
+					aStarPath, _ := FindPathWithAStar(field, field.GetEntrancePosition(), field.GetUpstairsPosition())
+					if path.GetDistance() != aStarPath.GetDistance() {
+						t.Fatalf("シード %d で SolveFloor と A* の距離が違う", seed)
+					}
+				}
+			})
+		}
+	}
+}
+
+func TestFindPathWithKeys_NotTD(t *testing.T) {
+	// 入口から上り階段の間に赤い扉があり、その鍵は脇道の奥にある。
+	text := "#######\n#@.R.<#\n#.#####\n#r#####\n#######"
+
+	t.Run("鍵を拾ってから扉を通る経路を返す", func(t *testing.T) {
+		field, _ := models.ParseFieldText(text)
+		path, ok := SolveFloor(field)
+		if !ok {
+			t.Fatal("解けない")
+		} else if path.GetDistance() != 8 {
+			t.Fatal("鍵への寄り道を含む最短経路ではない")
+		}
+		if _, ok := FindPath(field, field.GetEntrancePosition(), field.GetUpstairsPosition()); ok {
+			t.Fatal("鍵を使わない探索で扉を通っている")
+		}
+	})
+
+	t.Run("最初から持っている鍵の扉は通れる", func(t *testing.T) {
+		field, _ := models.ParseFieldText(text)
+		from := field.GetEntrancePosition()
+		to := field.GetUpstairsPosition()
+		path, ok := FindPathWithKeys(field, from, to, []models.ObjectKind{models.ObjectKindRedKey})
+		if !ok || path.GetDistance() != 4 {
+			t.Fatal("扉を通る最短経路ではない")
+		}
+		distances := MeasureDistancesWithKeys(field, from, []models.ObjectKind{models.ObjectKindBlueKey})
+		if distances[to.GetY()][to.GetX()] != -1 {
+			t.Fatal("色の違う鍵で扉を通っている")
+		}
+	})
+}
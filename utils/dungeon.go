@@ -0,0 +1,146 @@
+package utils
+
+import (
+	"math/rand"
+)
+
+// A rectangle of rooms in the coordinates of `mazeRooms`, i.e. the cell at Y=2*roomY+1, X=2*roomX+1.
+type dungeonRoom struct {
+	top int
+	left int
+	bottom int
+	right int
+}
+
+func (room *dungeonRoom) overlaps(other *dungeonRoom) bool {
+	return room.top <= other.bottom && other.top <= room.bottom &&
+		room.left <= other.right && other.left <= room.right
+}
+
+func (room *dungeonRoom) center() (int, int) {
+	return (room.top + room.bottom) / 2, (room.left + room.right) / 2
+}
+
+// It places rectangular rooms connected by corridors, like a roguelike dungeon.
+// The top-left and the bottom-right corners are always in rooms, so that the entrance and the upstairs are connected.
+// Unlike other generators, it makes loops and open spaces.
+type DungeonGenerator struct{}
+
+func (generator *DungeonGenerator) Generate(
+	rowLength int, columnLength int, random *rand.Rand) ([][]*mazeCell, error) {
+	cells, err := generateRawMazeMatrix(rowLength, columnLength)
+	if err != nil {
+		return cells, err
+	}
+	for _, row := range cells {
+		for _, cell := range row {
+			cell.Content = MazeCellContentUnbreakableWall
+		}
+	}
+	grid := createMazeRooms(cells)
+
+	// Rooms are up to a third of the field on each side.
+	maxRoomHeight := grid.rowLength/3 + 1
+	maxRoomWidth := grid.columnLength/3 + 1
+	createRandomRoom := func(top int, left int) *dungeonRoom {
+		return &dungeonRoom{
+			top: top,
+			left: left,
+			bottom: top + random.Intn(maxRoomHeight),
+			right: left + random.Intn(maxRoomWidth),
+		}
+	}
+	clamp := func(room *dungeonRoom) {
+		if room.bottom > grid.rowLength-1 {
+			room.bottom = grid.rowLength - 1
+		}
+		if room.right > grid.columnLength-1 {
+			room.right = grid.columnLength - 1
+		}
+	}
+
+	entranceRoom := createRandomRoom(0, 0)
+	clamp(entranceRoom)
+	upstairsRoom := createRandomRoom(0, 0)
+	upstairsRoom.top = grid.rowLength - 1 - (upstairsRoom.bottom - upstairsRoom.top)
+	upstairsRoom.left = grid.columnLength - 1 - (upstairsRoom.right - upstairsRoom.left)
+	upstairsRoom.bottom = grid.rowLength - 1
+	upstairsRoom.right = grid.columnLength - 1
+	rooms := []*dungeonRoom{entranceRoom, upstairsRoom}
+
+	// Other rooms do not overlap, but the number of tries is limited because small fields have no space.
+	maxRoomCount := 2 + grid.count()/12
+	for try := 0; try < maxRoomCount*4 && len(rooms) < maxRoomCount; try++ {
+		room := createRandomRoom(random.Intn(grid.rowLength), random.Intn(grid.columnLength))
+		clamp(room)
+		overlaps := false
+		for _, other := range rooms {
+			if room.overlaps(other) {
+				overlaps = true
+				break
+			}
+		}
+		if !overlaps {
+			rooms = append(rooms, room)
+		}
+	}
+
+	for _, room := range rooms {
+		for y := room.top*2 + 1; y <= room.bottom*2+1; y++ {
+			for x := room.left*2 + 1; x <= room.right*2+1; x++ {
+				cells[y][x].Content = MazeCellContentEmpty
+			}
+		}
+	}
+
+	// Connect each room to the nearest room that has been connected, so all rooms are connected.
+	digCorridor := func(fromY int, fromX int, toY int, toX int) {
+		for y, x := fromY, fromX; ; {
+			cells[y*2+1][x*2+1].Content = MazeCellContentEmpty
+			if y == toY && x == toX {
+				break
+			}
+			nextY, nextX := y, x
+			switch {
+			case x < toX:
+				nextX++
+			case x > toX:
+				nextX--
+			case y < toY:
+				nextY++
+			default:
+				nextY--
+			}
+			grid.connect(grid.at(y, x), grid.at(nextY, nextX))
+			y, x = nextY, nextX
+		}
+	}
+	for index := 1; index < len(rooms); index++ {
+		roomY, roomX := rooms[index].center()
+		nearestY, nearestX := rooms[0].center()
+		nearestDistance := -1
+		for _, connectedRoom := range rooms[:index] {
+			connectedY, connectedX := connectedRoom.center()
+			distance := abs(connectedY-roomY) + abs(connectedX-roomX)
+			if nearestDistance == -1 || distance < nearestDistance {
+				nearestY, nearestX, nearestDistance = connectedY, connectedX, distance
+			}
+		}
+		// Corridors bend at random corners.
+		if random.Intn(2) == 0 {
+			digCorridor(roomY, roomX, nearestY, nearestX)
+		} else {
+			digCorridor(nearestY, nearestX, roomY, roomX)
+		}
+	}
+
+	finishMaze(cells)
+	return cells, nil
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
@@ -0,0 +1,49 @@
+package utils
+
+import (
+	"encoding/json"
+	"github.com/pkg/errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+)
+
+// Unmarshal the JSON file into the `value`.
+// If the file does not exist, it leaves the `value` as it is and returns no error.
+func ReadJSONFile(path string, value interface{}) error {
+	content, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil
+	} else if err != nil {
+		return errors.WithStack(err)
+	}
+	unmarshalErr := json.Unmarshal(content, value)
+	if unmarshalErr != nil {
+		return errors.Wrapf(unmarshalErr, "The %q file is broken.", path)
+	}
+	return nil
+}
+
+// Marshal the `value` into the JSON file.
+func WriteJSONFile(path string, value interface{}) error {
+	content, marshalErr := json.MarshalIndent(value, "", "  ")
+	if marshalErr != nil {
+		return errors.WithStack(marshalErr)
+	}
+	// Write to another file and rename it, so that the file is not broken by an interruption.
+	temporaryPath := path + ".tmp"
+	writeErr := ioutil.WriteFile(temporaryPath, content, 0644)
+	if writeErr != nil {
+		return errors.WithStack(writeErr)
+	}
+	return errors.WithStack(os.Rename(temporaryPath, path))
+}
+
+// Return the path of the file in the home directory.
+func GetHomeFilePath(fileName string) (string, error) {
+	homeDirectory, err := os.UserHomeDir()
+	if err != nil {
+		return "", errors.WithStack(err)
+	}
+	return filepath.Join(homeDirectory, fileName), nil
+}
@@ -0,0 +1,55 @@
+package utils
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestWriteJSONFile_NotTD(t *testing.T) {
+	directory, err := ioutil.TempDir("", "utils")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		os.RemoveAll(directory)
+	})
+	path := filepath.Join(directory, "data.json")
+
+	t.Run("ファイルが存在しないときは値を変えない", func(t *testing.T) {
+		counts := map[string]int{"a": 1}
+		readErr := ReadJSONFile(path, &counts)
+		if readErr != nil {
+			t.Fatal(readErr)
+		} else if len(counts) != 1 || counts["a"] != 1 {
+			t.Fatal("値が変わっている")
+		}
+	})
+
+	t.Run("書き込んだ値を読める", func(t *testing.T) {
+		writeErr := WriteJSONFile(path, map[string]int{"b": 2})
+		if writeErr != nil {
+			t.Fatal(writeErr)
+		}
+		counts := make(map[string]int)
+		readErr := ReadJSONFile(path, &counts)
+		if readErr != nil {
+			t.Fatal(readErr)
+		} else if counts["b"] != 2 {
+			t.Fatal("値が違う")
+		}
+		_, statErr := os.Stat(path + ".tmp")
+		if !os.IsNotExist(statErr) {
+			t.Fatal("一時ファイルが残っている")
+		}
+	})
+
+	t.Run("壊れたファイルはエラーを返す", func(t *testing.T) {
+		ioutil.WriteFile(path, []byte("{"), 0644)
+		counts := make(map[string]int)
+		if ReadJSONFile(path, &counts) == nil {
+			t.Fatal("エラーを返していない")
+		}
+	})
+}
@@ -31,6 +31,8 @@ func generateRawMazeMatrix(rowLength int, columnLength int) ([][]*mazeCell, erro
 	clusterIndex := 0
 	for y := 0; y < rowLength; y++ {
 		row := make([]*mazeCell, columnLength)
+		// Allocate a row at once, because huge mazes have millions of cells.
+		rowCells := make([]mazeCell, columnLength)
 		for x := 0; x < columnLength; x++ {
 			content := MazeCellContentUnbreakableWall
 			if (y%2 == 1 && x%2 == 1) {
@@ -41,13 +43,13 @@ func generateRawMazeMatrix(rowLength int, columnLength int) ([][]*mazeCell, erro
 				(y%2 == 0 && x%2 == 1 || y%2 == 1 && x%2 == 0)) {
 				content = MazeCellContentBreakableWall
 			}
-			cell := mazeCell{
+			rowCells[x] = mazeCell{
 				Content: content,
 				ClusterIndex: clusterIndex,
 				Y: y,
 				X: x,
 			}
-			row[x] = &cell
+			row[x] = &rowCells[x]
 			clusterIndex++
 		}
 		cells[y] = row
@@ -55,6 +57,46 @@ func generateRawMazeMatrix(rowLength int, columnLength int) ([][]*mazeCell, erro
 	return cells, nil
 }
 
+// A disjoint-set forest of cluster indexes.
+// It uses int32 in one slice, because huge mazes are bound by memory access rather than calculation.
+type clusterSet struct {
+	// The parent of each index. A root has the negative number of members of its cluster instead.
+	parents []int32
+}
+
+// Find the representative of the cluster with path halving.
+func (set *clusterSet) find(index int32) int32 {
+	for set.parents[index] >= 0 {
+		parent := set.parents[index]
+		grandparent := set.parents[parent]
+		if grandparent < 0 {
+			return parent
+		}
+		set.parents[index] = grandparent
+		index = grandparent
+	}
+	return index
+}
+
+// Merge two clusters of roots. The smaller one is attached to the larger one to keep trees shallow.
+func (set *clusterSet) unionRoots(aRoot int32, bRoot int32) {
+	if set.parents[aRoot] > set.parents[bRoot] {
+		aRoot, bRoot = bRoot, aRoot
+	}
+	set.parents[aRoot] += set.parents[bRoot]
+	set.parents[bRoot] = aRoot
+}
+
+func createClusterSet(size int) *clusterSet {
+	parents := make([]int32, size)
+	for index := range parents {
+		parents[index] = -1
+	}
+	return &clusterSet{
+		parents: parents,
+	}
+}
+
 // Generate a maze with the clustering method.
 //
 // The maze generation algorithm referred to the following article.
@@ -66,29 +108,37 @@ func generateRawMazeMatrix(rowLength int, columnLength int) ([][]*mazeCell, erro
 // #     #
 // #     #
 // #######
-func GenerateMaze(rowLength int, columnLength int) ([][]*mazeCell, error) {
+//
+// The same `random` source always generates the same maze.
+// Clusters are merged with a disjoint-set, so it takes almost linear time, e.g. 2001x2001 is fine.
+func GenerateMaze(rowLength int, columnLength int, random *rand.Rand) ([][]*mazeCell, error) {
 	cells, err := generateRawMazeMatrix(rowLength, columnLength)
 	if err != nil {
 		return cells, err
 	}
 
-	breakableWalls := make([]*mazeCell, 0)
+	// Walls are handled by their cluster indexes, i.e. y * columnLength + x, instead of pointers to cells.
+	// It is much faster for huge mazes, because the shuffled walls are accessed randomly.
+	breakableWalls := make([]int32, 0)
 	for _, row := range cells {
 		for _, cell := range row {
 			if cell.Content == MazeCellContentBreakableWall {
-				breakableWalls = append(breakableWalls, cell)
+				breakableWalls = append(breakableWalls, int32(cell.ClusterIndex))
 			}
 		}
 	}
 
-	rand.Shuffle(len(breakableWalls), func (i, j int) {
+	random.Shuffle(len(breakableWalls), func (i, j int) {
 		breakableWalls[i], breakableWalls[j] = breakableWalls[j], breakableWalls[i]
 	})
 
+	clusters := createClusterSet(rowLength * columnLength)
+	// Results are written to cells later in order, because writing them in the shuffled order is slow.
+	brokenWalls := make([]bool, rowLength * columnLength)
 	for _, breakableWall := range breakableWalls {
-		var a *mazeCell
-		var b *mazeCell
-		upperCell := cells[breakableWall.Y - 1][breakableWall.X]
+		y := int(breakableWall) / columnLength
+		var a int32
+		var b int32
 		//
 		// # = MazeCellContentUnbreakableWall
 		// * = MazeCellContentBreakableWall
@@ -100,35 +150,49 @@ func GenerateMaze(rowLength int, columnLength int) ([][]*mazeCell, error) {
 		// *b*
 		// #*#
 		//
-		if upperCell.Content == MazeCellContentEmpty {
-			a = upperCell
-			b = cells[breakableWall.Y + 1][breakableWall.X]
+		if y%2 == 0 {
+			a = breakableWall - int32(columnLength)
+			b = breakableWall + int32(columnLength)
 		//
 		// #*#*#
 		// #b@a#
 		// #*#*#
 		//
 		} else {
-			a = cells[breakableWall.Y][breakableWall.X + 1]
-			b = cells[breakableWall.Y][breakableWall.X - 1]
+			a = breakableWall + 1
+			b = breakableWall - 1
+		}
+
+		aRoot := clusters.find(a)
+		bRoot := clusters.find(b)
+		if aRoot != bRoot {
+			brokenWalls[breakableWall] = true
+			clusters.unionRoots(aRoot, bRoot)
 		}
+	}
 
-		if a.ClusterIndex != b.ClusterIndex {
-			aci := a.ClusterIndex
-			bci := b.ClusterIndex
-			breakableWall.Content = MazeCellContentEmpty
-			breakableWall.ClusterIndex = aci
-			for _, row := range cells {
-				for _, cell := range row {
-					if cell.ClusterIndex == bci {
-						cell.ClusterIndex = aci
-					}
+	// All empty cells are joined into one cluster, and walls remain in their own clusters.
+	// So they are labeled without finding, in the same way as relabeling them one by one.
+	pathClusterIndex := int(clusters.find(int32(cells[1][1].ClusterIndex)))
+	for _, row := range cells {
+		for _, cell := range row {
+			if cell.Content == MazeCellContentBreakableWall {
+				if brokenWalls[cell.ClusterIndex] {
+					cell.Content = MazeCellContentEmpty
+				} else {
+					cell.Content = MazeCellContentUnbreakableWall
 				}
 			}
-		} else {
-			breakableWall.Content = MazeCellContentUnbreakableWall
+			if cell.Content == MazeCellContentEmpty {
+				cell.ClusterIndex = pathClusterIndex
+			}
 		}
 	}
 
 	return cells, nil
 }
+
+// Generate a maze with `GenerateMaze` from the seed.
+func GenerateMazeFromSeed(rowLength int, columnLength int, seed int64) ([][]*mazeCell, error) {
+	return GenerateMaze(rowLength, columnLength, rand.New(rand.NewSource(seed)))
+}
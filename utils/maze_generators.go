@@ -0,0 +1,396 @@
+package utils
+
+//
+// NOTE: Each algorithm breaks walls of the matrix from `generateRawMazeMatrix`.
+//       Empty cells at Y=2n+1, X=2n+1 are called "rooms", and a broken wall between two rooms is a passage.
+//       All of them except `DungeonGenerator` generate perfect mazes,
+//       i.e. there is exactly one path between any two empty cells. `BraidMaze` makes loops in them afterwards.
+//
+
+import (
+	"github.com/pkg/errors"
+	"math"
+	"math/rand"
+	"sort"
+)
+
+type MazeGenerator interface {
+	// It returns the same matrix as `GenerateMaze`. The same `random` source always generates the same maze.
+	Generate(rowLength int, columnLength int, random *rand.Rand) ([][]*mazeCell, error)
+}
+
+// The default generator.
+const DefaultMazeGeneratorName = "clustering"
+
+var mazeGenerators = map[string]MazeGenerator{
+	"backtracker": &RecursiveBacktrackerMazeGenerator{},
+	"binary-tree": &BinaryTreeMazeGenerator{},
+	"clustering": &ClusteringMazeGenerator{},
+	"dungeon": &DungeonGenerator{},
+	"eller": &EllerMazeGenerator{},
+	"prim": &PrimMazeGenerator{},
+	"wilson": &WilsonMazeGenerator{},
+}
+
+func FindMazeGenerator(name string) (MazeGenerator, error) {
+	generator, ok := mazeGenerators[name]
+	if !ok {
+		return nil, errors.Errorf("The %q maze generator does not exist.", name)
+	}
+	return generator, nil
+}
+
+// Return names of all generators in alphabetical order.
+func GetMazeGeneratorNames() []string {
+	names := make([]string, 0, len(mazeGenerators))
+	for name := range mazeGenerators {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Rooms of the maze, i.e. empty cells at Y=2n+1, X=2n+1.
+type mazeRooms struct {
+	cells [][]*mazeCell
+	rowLength int
+	columnLength int
+}
+
+func (rooms *mazeRooms) at(roomY int, roomX int) *mazeCell {
+	return rooms.cells[roomY*2+1][roomX*2+1]
+}
+
+func (rooms *mazeRooms) count() int {
+	return rooms.rowLength * rooms.columnLength
+}
+
+// The index of the room is used for sets of rooms.
+func (rooms *mazeRooms) indexOf(room *mazeCell) int {
+	return (room.Y-1)/2*rooms.columnLength + (room.X-1)/2
+}
+
+// Return rooms next to the room in the order of up, right, down and left.
+func (rooms *mazeRooms) neighborsOf(room *mazeCell) []*mazeCell {
+	roomY := (room.Y - 1) / 2
+	roomX := (room.X - 1) / 2
+	neighbors := make([]*mazeCell, 0, 4)
+	if roomY > 0 {
+		neighbors = append(neighbors, rooms.at(roomY-1, roomX))
+	}
+	if roomX < rooms.columnLength-1 {
+		neighbors = append(neighbors, rooms.at(roomY, roomX+1))
+	}
+	if roomY < rooms.rowLength-1 {
+		neighbors = append(neighbors, rooms.at(roomY+1, roomX))
+	}
+	if roomX > 0 {
+		neighbors = append(neighbors, rooms.at(roomY, roomX-1))
+	}
+	return neighbors
+}
+
+// Break the wall between two neighboring rooms.
+func (rooms *mazeRooms) connect(a *mazeCell, b *mazeCell) {
+	rooms.cells[(a.Y+b.Y)/2][(a.X+b.X)/2].Content = MazeCellContentEmpty
+}
+
+func createMazeRooms(cells [][]*mazeCell) *mazeRooms {
+	return &mazeRooms{
+		cells: cells,
+		rowLength: (len(cells) - 1) / 2,
+		columnLength: (len(cells[0]) - 1) / 2,
+	}
+}
+
+// Turn remaining breakable walls into unbreakable walls, and label all empty cells with one cluster.
+// Then the result is the same as `GenerateMaze`'s one.
+func finishMaze(cells [][]*mazeCell) {
+	pathClusterIndex := cells[1][1].ClusterIndex
+	for _, row := range cells {
+		for _, cell := range row {
+			if cell.Content == MazeCellContentBreakableWall {
+				cell.Content = MazeCellContentUnbreakableWall
+			} else if cell.Content == MazeCellContentEmpty {
+				cell.ClusterIndex = pathClusterIndex
+			}
+		}
+	}
+}
+
+// The clustering method of `GenerateMaze`. It makes many short dead ends.
+type ClusteringMazeGenerator struct{}
+
+func (generator *ClusteringMazeGenerator) Generate(
+	rowLength int, columnLength int, random *rand.Rand) ([][]*mazeCell, error) {
+	return GenerateMaze(rowLength, columnLength, random)
+}
+
+// It digs into unvisited rooms as deep as possible, so it makes long winding corridors.
+// It uses a stack instead of recursion, so that huge mazes do not exhaust the call stack.
+type RecursiveBacktrackerMazeGenerator struct{}
+
+func (generator *RecursiveBacktrackerMazeGenerator) Generate(
+	rowLength int, columnLength int, random *rand.Rand) ([][]*mazeCell, error) {
+	cells, err := generateRawMazeMatrix(rowLength, columnLength)
+	if err != nil {
+		return cells, err
+	}
+	rooms := createMazeRooms(cells)
+	visited := make([]bool, rooms.count())
+	start := rooms.at(0, 0)
+	visited[rooms.indexOf(start)] = true
+	stack := []*mazeCell{start}
+	for len(stack) > 0 {
+		current := stack[len(stack)-1]
+		unvisitedNeighbors := make([]*mazeCell, 0, 4)
+		for _, neighbor := range rooms.neighborsOf(current) {
+			if !visited[rooms.indexOf(neighbor)] {
+				unvisitedNeighbors = append(unvisitedNeighbors, neighbor)
+			}
+		}
+		if len(unvisitedNeighbors) == 0 {
+			stack = stack[:len(stack)-1]
+			continue
+		}
+		next := unvisitedNeighbors[random.Intn(len(unvisitedNeighbors))]
+		rooms.connect(current, next)
+		visited[rooms.indexOf(next)] = true
+		stack = append(stack, next)
+	}
+	finishMaze(cells)
+	return cells, nil
+}
+
+// It grows the maze from a room by adding a random frontier room each time.
+// It makes many short branches radiating from the start.
+type PrimMazeGenerator struct{}
+
+func (generator *PrimMazeGenerator) Generate(
+	rowLength int, columnLength int, random *rand.Rand) ([][]*mazeCell, error) {
+	cells, err := generateRawMazeMatrix(rowLength, columnLength)
+	if err != nil {
+		return cells, err
+	}
+	rooms := createMazeRooms(cells)
+	inMaze := make([]bool, rooms.count())
+	inFrontier := make([]bool, rooms.count())
+	frontier := make([]*mazeCell, 0)
+	addToMaze := func(room *mazeCell) {
+		inMaze[rooms.indexOf(room)] = true
+		for _, neighbor := range rooms.neighborsOf(room) {
+			index := rooms.indexOf(neighbor)
+			if !inMaze[index] && !inFrontier[index] {
+				inFrontier[index] = true
+				frontier = append(frontier, neighbor)
+			}
+		}
+	}
+	addToMaze(rooms.at(random.Intn(rooms.rowLength), random.Intn(rooms.columnLength)))
+	for len(frontier) > 0 {
+		frontierIndex := random.Intn(len(frontier))
+		room := frontier[frontierIndex]
+		frontier[frontierIndex] = frontier[len(frontier)-1]
+		frontier = frontier[:len(frontier)-1]
+		connectedNeighbors := make([]*mazeCell, 0, 4)
+		for _, neighbor := range rooms.neighborsOf(room) {
+			if inMaze[rooms.indexOf(neighbor)] {
+				connectedNeighbors = append(connectedNeighbors, neighbor)
+			}
+		}
+		rooms.connect(room, connectedNeighbors[random.Intn(len(connectedNeighbors))])
+		addToMaze(room)
+	}
+	finishMaze(cells)
+	return cells, nil
+}
+
+// It connects loop-erased random walks to the maze, so every maze is chosen uniformly.
+// It is slower than others at first, because the first walks wander until they hit the maze.
+type WilsonMazeGenerator struct{}
+
+func (generator *WilsonMazeGenerator) Generate(
+	rowLength int, columnLength int, random *rand.Rand) ([][]*mazeCell, error) {
+	cells, err := generateRawMazeMatrix(rowLength, columnLength)
+	if err != nil {
+		return cells, err
+	}
+	rooms := createMazeRooms(cells)
+	inMaze := make([]bool, rooms.count())
+	// The direction that the walk left each room last time. Overwriting it erases loops.
+	nextRooms := make([]*mazeCell, rooms.count())
+	inMaze[random.Intn(rooms.count())] = true
+	for roomY := 0; roomY < rooms.rowLength; roomY++ {
+		for roomX := 0; roomX < rooms.columnLength; roomX++ {
+			start := rooms.at(roomY, roomX)
+			if inMaze[rooms.indexOf(start)] {
+				continue
+			}
+			for current := start; !inMaze[rooms.indexOf(current)]; {
+				neighbors := rooms.neighborsOf(current)
+				next := neighbors[random.Intn(len(neighbors))]
+				nextRooms[rooms.indexOf(current)] = next
+				current = next
+			}
+			for current := start; !inMaze[rooms.indexOf(current)]; {
+				next := nextRooms[rooms.indexOf(current)]
+				rooms.connect(current, next)
+				inMaze[rooms.indexOf(current)] = true
+				current = next
+			}
+		}
+	}
+	finishMaze(cells)
+	return cells, nil
+}
+
+// It makes the maze row by row, keeping sets of connected rooms only in the current row.
+// It makes mostly horizontal corridors.
+type EllerMazeGenerator struct{}
+
+func (generator *EllerMazeGenerator) Generate(
+	rowLength int, columnLength int, random *rand.Rand) ([][]*mazeCell, error) {
+	cells, err := generateRawMazeMatrix(rowLength, columnLength)
+	if err != nil {
+		return cells, err
+	}
+	rooms := createMazeRooms(cells)
+	sets := createClusterSet(rooms.count())
+	for roomY := 0; roomY < rooms.rowLength; roomY++ {
+		isLastRow := roomY == rooms.rowLength-1
+
+		// Join neighbors in the row at random. In the last row, all sets must be joined.
+		for roomX := 0; roomX < rooms.columnLength-1; roomX++ {
+			left := rooms.at(roomY, roomX)
+			right := rooms.at(roomY, roomX+1)
+			leftSet := sets.find(int32(rooms.indexOf(left)))
+			rightSet := sets.find(int32(rooms.indexOf(right)))
+			if leftSet != rightSet && (isLastRow || random.Intn(2) == 0) {
+				rooms.connect(left, right)
+				sets.unionRoots(leftSet, rightSet)
+			}
+		}
+		if isLastRow {
+			break
+		}
+
+		// Each set extends down at least once.
+		roomXsOfSets := make(map[int32][]int)
+		setOrder := make([]int32, 0)
+		for roomX := 0; roomX < rooms.columnLength; roomX++ {
+			set := sets.find(int32(rooms.indexOf(rooms.at(roomY, roomX))))
+			if _, ok := roomXsOfSets[set]; !ok {
+				setOrder = append(setOrder, set)
+			}
+			roomXsOfSets[set] = append(roomXsOfSets[set], roomX)
+		}
+		for _, set := range setOrder {
+			roomXs := roomXsOfSets[set]
+			random.Shuffle(len(roomXs), func(i, j int) {
+				roomXs[i], roomXs[j] = roomXs[j], roomXs[i]
+			})
+			extensionCount := 1 + random.Intn(len(roomXs))
+			for _, roomX := range roomXs[:extensionCount] {
+				upper := rooms.at(roomY, roomX)
+				lower := rooms.at(roomY+1, roomX)
+				rooms.connect(upper, lower)
+				sets.unionRoots(sets.find(int32(rooms.indexOf(upper))), sets.find(int32(rooms.indexOf(lower))))
+			}
+		}
+	}
+	finishMaze(cells)
+	return cells, nil
+}
+
+// Each room connects to either the upper or the left room.
+// It is the fastest, but the top row and the left column are always straight corridors.
+type BinaryTreeMazeGenerator struct{}
+
+func (generator *BinaryTreeMazeGenerator) Generate(
+	rowLength int, columnLength int, random *rand.Rand) ([][]*mazeCell, error) {
+	cells, err := generateRawMazeMatrix(rowLength, columnLength)
+	if err != nil {
+		return cells, err
+	}
+	rooms := createMazeRooms(cells)
+	for roomY := 0; roomY < rooms.rowLength; roomY++ {
+		for roomX := 0; roomX < rooms.columnLength; roomX++ {
+			room := rooms.at(roomY, roomX)
+			switch {
+			case roomY == 0 && roomX == 0:
+			case roomY == 0:
+				rooms.connect(room, rooms.at(roomY, roomX-1))
+			case roomX == 0:
+				rooms.connect(room, rooms.at(roomY-1, roomX))
+			case random.Intn(2) == 0:
+				rooms.connect(room, rooms.at(roomY, roomX-1))
+			default:
+				rooms.connect(room, rooms.at(roomY-1, roomX))
+			}
+		}
+	}
+	finishMaze(cells)
+	return cells, nil
+}
+
+// Whether the cell is a wall at the same position as a breakable wall of `generateRawMazeMatrix`,
+// and both of its sides are empty. Breaking it joins two rooms and never makes an isolated space.
+// Both sides are checked, because rooms of dungeons are not always empty.
+func IsWallBetweenRooms(cells [][]*mazeCell, y int, x int) bool {
+	rowLength := len(cells)
+	columnLength := len(cells[0])
+	if y < 1 || y >= rowLength-1 || x < 1 || x >= columnLength-1 || cells[y][x].Content == MazeCellContentEmpty {
+		return false
+	}
+	isBetweenVerticalRooms := y%2 == 0 && x%2 == 1 &&
+		cells[y-1][x].Content == MazeCellContentEmpty && cells[y+1][x].Content == MazeCellContentEmpty
+	isBetweenHorizontalRooms := y%2 == 1 && x%2 == 0 &&
+		cells[y][x-1].Content == MazeCellContentEmpty && cells[y][x+1].Content == MazeCellContentEmpty
+	return isBetweenVerticalRooms || isBetweenHorizontalRooms
+}
+
+// Break the `loopDensity` fraction of walls between rooms to make loops.
+// The maze is no longer perfect, so players can choose routes.
+// The `loopDensity` must be between 0 and 1. 0 does nothing and 1 breaks all of them.
+func BraidMaze(cells [][]*mazeCell, loopDensity float64, random *rand.Rand) error {
+	if loopDensity < 0 || loopDensity > 1 {
+		return errors.Errorf("The loop density must be between 0 and 1, but it is %v.", loopDensity)
+	}
+	innerWalls := make([]*mazeCell, 0)
+	for y, row := range cells {
+		for x, cell := range row {
+			if IsWallBetweenRooms(cells, y, x) {
+				innerWalls = append(innerWalls, cell)
+			}
+		}
+	}
+	random.Shuffle(len(innerWalls), func(i, j int) {
+		innerWalls[i], innerWalls[j] = innerWalls[j], innerWalls[i]
+	})
+	pathClusterIndex := cells[1][1].ClusterIndex
+	breakingCount := int(math.Round(float64(len(innerWalls)) * loopDensity))
+	for _, wall := range innerWalls[:breakingCount] {
+		wall.Content = MazeCellContentEmpty
+		wall.ClusterIndex = pathClusterIndex
+	}
+	return nil
+}
+
+// It braids mazes of the `Base` generator with `BraidMaze`.
+type BraidedMazeGenerator struct {
+	Base MazeGenerator
+	LoopDensity float64
+}
+
+func (generator *BraidedMazeGenerator) Generate(
+	rowLength int, columnLength int, random *rand.Rand) ([][]*mazeCell, error) {
+	cells, err := generator.Base.Generate(rowLength, columnLength, random)
+	if err != nil {
+		return cells, err
+	}
+	braidErr := BraidMaze(cells, generator.LoopDensity, random)
+	if braidErr != nil {
+		return cells, braidErr
+	}
+	return cells, nil
+}
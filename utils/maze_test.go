@@ -2,6 +2,7 @@ package utils
 
 import (
 	"fmt"
+	"math"
 	"math/rand"
 	"reflect"
 	"testing"
@@ -208,8 +209,7 @@ func TestGenerateMaze_NotTD(t *testing.T) {
 			title := fmt.Sprintf("行%d*列%dの迷路を生成するとき", testCase.rowLength, testCase.columnLength)
 			t.Run(title, func(t *testing.T) {
 				seed++
-				rand.Seed(seed)
-				cells, _ := GenerateMaze(testCase.rowLength, testCase.columnLength)
+				cells, _ := GenerateMaze(testCase.rowLength, testCase.columnLength, rand.New(rand.NewSource(seed)))
 
 				t.Run("行と1行目の列の数が指定した値と等しい", func(t *testing.T) {
 					if len(cells) != testCase.rowLength {
@@ -256,3 +256,343 @@ func TestGenerateMaze_NotTD(t *testing.T) {
 		}
 	})
 }
+
+func TestGenerateMazeFromSeed_NotTD(t *testing.T) {
+	mazeToText := func(cells [][]*mazeCell) string {
+		text := ""
+		for _, row := range cells {
+			for _, cell := range row {
+				if cell.Content == MazeCellContentEmpty {
+					text += " "
+				} else {
+					text += "#"
+				}
+			}
+			text += "\n"
+		}
+		return text
+	}
+
+	t.Run("同じシードからは同じ迷路を生成する", func(t *testing.T) {
+		a, _ := GenerateMazeFromSeed(21, 21, 123)
+		b, _ := GenerateMazeFromSeed(21, 21, 123)
+		if mazeToText(a) != mazeToText(b) {
+			t.Fatal("迷路が異なる")
+		}
+	})
+
+	t.Run("異なるシードからは異なる迷路を生成する", func(t *testing.T) {
+		a, _ := GenerateMazeFromSeed(21, 21, 123)
+		b, _ := GenerateMazeFromSeed(21, 21, 124)
+		if mazeToText(a) == mazeToText(b) {
+			t.Fatal("迷路が同じ")
+		}
+	})
+}
+
+// 空セルを幅優先で辿り、踏んだ空セルの数を返す。巨大な迷路でも再帰しない。
+func countReachableEmptyCells(cells [][]*mazeCell, start *mazeCell) int {
+	columnLength := len(cells[0])
+	visited := make([]bool, len(cells)*columnLength)
+	visited[start.Y*columnLength+start.X] = true
+	count := 1
+	queue := []*mazeCell{start}
+	for len(queue) > 0 {
+		cell := queue[0]
+		queue = queue[1:]
+		for _, delta := range [][]int{{-1, 0}, {0, 1}, {1, 0}, {0, -1}} {
+			nextCell := cells[cell.Y+delta[0]][cell.X+delta[1]]
+			index := nextCell.Y*columnLength + nextCell.X
+			if !visited[index] && nextCell.Content == MazeCellContentEmpty {
+				visited[index] = true
+				count++
+				queue = append(queue, nextCell)
+			}
+		}
+	}
+	return count
+}
+
+func TestGenerateMaze_Huge_NotTD(t *testing.T) {
+	if testing.Short() {
+		t.Skip("巨大な迷路の生成は short モードでは省略する")
+	}
+
+	t.Run("2001*2001の迷路を生成できる", func(t *testing.T) {
+		cells, err := GenerateMazeFromSeed(2001, 2001, 1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		emptyCellCount := 0
+		for _, row := range cells {
+			for _, cell := range row {
+				if cell.Content == MazeCellContentBreakableWall {
+					t.Fatalf("Y=%d,X=%d は壊せる壁である", cell.Y, cell.X)
+				} else if cell.Content == MazeCellContentEmpty {
+					emptyCellCount++
+				}
+			}
+		}
+		// 全域木なので、空セルは部屋 1000*1000 とそれらを繋ぐ 1000*1000-1 の通路である。
+		if emptyCellCount != 1000*1000*2-1 {
+			t.Fatal("空セルの数が全域木ではない")
+		} else if countReachableEmptyCells(cells, cells[1][1]) != emptyCellCount {
+			t.Fatal("全ての空セルが結合されていない")
+		}
+	})
+
+	t.Run("空セルは全て同じクラスタに属する", func(t *testing.T) {
+		cells, _ := GenerateMazeFromSeed(51, 51, 1)
+		for _, row := range cells {
+			for _, cell := range row {
+				if cell.Content == MazeCellContentEmpty && cell.ClusterIndex != cells[1][1].ClusterIndex {
+					t.Fatalf("Y=%d,X=%d が別のクラスタである", cell.Y, cell.X)
+				}
+			}
+		}
+	})
+}
+
+func BenchmarkGenerateMaze_2001x2001(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		GenerateMazeFromSeed(2001, 2001, int64(i))
+	}
+}
+
+func TestMazeGenerator_Generate_NotTD(t *testing.T) {
+	for _, name := range GetMazeGeneratorNames() {
+		// ダンジョンは完全迷路ではない。
+		if name == "dungeon" {
+			continue
+		}
+		generator, _ := FindMazeGenerator(name)
+		t.Run(fmt.Sprintf("%s で", name), func(t *testing.T) {
+			testCases := []struct {
+				rowLength    int
+				columnLength int
+			}{
+				{rowLength: 3, columnLength: 3},
+				{rowLength: 5, columnLength: 3},
+				{rowLength: 3, columnLength: 5},
+				{rowLength: 13, columnLength: 21},
+				{rowLength: 51, columnLength: 31},
+			}
+			for _, testCase := range testCases {
+				title := fmt.Sprintf("行%d*列%dの完全迷路を生成する", testCase.rowLength, testCase.columnLength)
+				t.Run(title, func(t *testing.T) {
+					for seed := int64(0); seed < 10; seed++ {
+						cells, err := generator.Generate(testCase.rowLength, testCase.columnLength, rand.New(rand.NewSource(seed)))
+						if err != nil {
+							t.Fatal(err)
+						}
+						emptyCellCount := 0
+						for _, row := range cells {
+							for _, cell := range row {
+								if cell.Content == MazeCellContentBreakableWall {
+									t.Fatalf("Y=%d,X=%d は壊せる壁である", cell.Y, cell.X)
+								} else if cell.Content == MazeCellContentEmpty {
+									emptyCellCount++
+									if cell.ClusterIndex != cells[1][1].ClusterIndex {
+										t.Fatalf("Y=%d,X=%d が別のクラスタである", cell.Y, cell.X)
+									}
+								}
+							}
+						}
+						// 全ての部屋が繋がり、通路が部屋の数より一つ少なければ完全迷路である。
+						roomCount := ((testCase.rowLength - 1) / 2) * ((testCase.columnLength - 1) / 2)
+						if emptyCellCount != roomCount*2-1 {
+							t.Fatal("完全迷路の空セルの数ではない")
+						} else if countReachableEmptyCells(cells, cells[1][1]) != emptyCellCount {
+							t.Fatal("全ての空セルが結合されていない")
+						}
+					}
+				})
+			}
+
+			t.Run("同じシードからは同じ迷路を生成する", func(t *testing.T) {
+				a, _ := generator.Generate(21, 21, rand.New(rand.NewSource(123)))
+				b, _ := generator.Generate(21, 21, rand.New(rand.NewSource(123)))
+				for y, row := range a {
+					for x, cell := range row {
+						if cell.Content != b[y][x].Content {
+							t.Fatal("迷路が異なる")
+						}
+					}
+				}
+			})
+		})
+	}
+
+	t.Run("存在しないアルゴリズムはエラーを返す", func(t *testing.T) {
+		_, err := FindMazeGenerator("unknown")
+		if err == nil {
+			t.Fatal("エラーを返さない")
+		}
+	})
+}
+
+func TestBraidMaze_NotTD(t *testing.T) {
+	countEmptyCells := func(cells [][]*mazeCell) int {
+		count := 0
+		for _, row := range cells {
+			for _, cell := range row {
+				if cell.Content == MazeCellContentEmpty {
+					count++
+				}
+			}
+		}
+		return count
+	}
+
+	t.Run("0のときは完全迷路のままである", func(t *testing.T) {
+		cells, _ := GenerateMazeFromSeed(21, 21, 1)
+		before := countEmptyCells(cells)
+		BraidMaze(cells, 0, rand.New(rand.NewSource(1)))
+		if countEmptyCells(cells) != before {
+			t.Fatal("壁が壊されている")
+		}
+	})
+
+	t.Run("指定した割合の部屋の間の壁を壊す", func(t *testing.T) {
+		cells, _ := GenerateMazeFromSeed(21, 21, 1)
+		// 部屋 10*10 の間の壁は 10*9*2 で、そのうち 10*10-1 は既に通路である。
+		innerWallCount := 10*9*2 - (10*10 - 1)
+		before := countEmptyCells(cells)
+		BraidMaze(cells, 0.5, rand.New(rand.NewSource(1)))
+		if countEmptyCells(cells)-before != int(math.Round(float64(innerWallCount)*0.5)) {
+			t.Fatal("壊した壁の数が違う")
+		}
+	})
+
+	t.Run("1のときは部屋の間の壁を全て壊し、外周と柱は残す", func(t *testing.T) {
+		cells, _ := GenerateMazeFromSeed(7, 7, 1)
+		BraidMaze(cells, 1, rand.New(rand.NewSource(1)))
+		for y, row := range cells {
+			for x, cell := range row {
+				isEdge := y == 0 || y == 6 || x == 0 || x == 6
+				isPillar := y%2 == 0 && x%2 == 0
+				if (isEdge || isPillar) != (cell.Content == MazeCellContentUnbreakableWall) {
+					t.Fatalf("Y=%d,X=%d が違う", y, x)
+				}
+			}
+		}
+	})
+
+	t.Run("範囲外の割合はエラーを返す", func(t *testing.T) {
+		cells, _ := GenerateMazeFromSeed(7, 7, 1)
+		if BraidMaze(cells, 1.5, rand.New(rand.NewSource(1))) == nil {
+			t.Fatal("エラーを返さない")
+		}
+	})
+
+	t.Run("ダンジョンの壁に囲まれた位置は壊さない", func(t *testing.T) {
+		for seed := int64(0); seed < 20; seed++ {
+			cells, _ := (&DungeonGenerator{}).Generate(13, 21, rand.New(rand.NewSource(seed)))
+			BraidMaze(cells, 1, rand.New(rand.NewSource(seed)))
+			if countReachableEmptyCells(cells, cells[1][1]) != countEmptyCells(cells) {
+				t.Fatalf("シード %d で孤立した空セルがある", seed)
+			}
+		}
+	})
+
+	t.Run("BraidedMazeGenerator は全ての空セルが結合された迷路を生成する", func(t *testing.T) {
+		generator := &BraidedMazeGenerator{Base: &PrimMazeGenerator{}, LoopDensity: 0.3}
+		cells, _ := generator.Generate(21, 21, rand.New(rand.NewSource(1)))
+		if countReachableEmptyCells(cells, cells[1][1]) != countEmptyCells(cells) {
+			t.Fatal("全ての空セルが結合されていない")
+		}
+	})
+}
+
+func TestDungeonGenerator_Generate_NotTD(t *testing.T) {
+	generator := &DungeonGenerator{}
+
+	testCases := []struct {
+		rowLength    int
+		columnLength int
+	}{
+		{rowLength: 3, columnLength: 3},
+		{rowLength: 3, columnLength: 7},
+		{rowLength: 13, columnLength: 21},
+		{rowLength: 51, columnLength: 31},
+	}
+	for _, testCase := range testCases {
+		title := fmt.Sprintf("行%d*列%dの全ての空セルが繋がったダンジョンを生成する", testCase.rowLength, testCase.columnLength)
+		t.Run(title, func(t *testing.T) {
+			for seed := int64(0); seed < 20; seed++ {
+				cells, err := generator.Generate(testCase.rowLength, testCase.columnLength, rand.New(rand.NewSource(seed)))
+				if err != nil {
+					t.Fatal(err)
+				}
+				emptyCellCount := 0
+				for y, row := range cells {
+					for x, cell := range row {
+						isEdge := y == 0 || y == testCase.rowLength-1 || x == 0 || x == testCase.columnLength-1
+						if cell.Content == MazeCellContentBreakableWall {
+							t.Fatalf("Y=%d,X=%d は壊せる壁である", y, x)
+						} else if cell.Content == MazeCellContentEmpty {
+							emptyCellCount++
+							if isEdge {
+								t.Fatalf("Y=%d,X=%d の外周が空いている", y, x)
+							} else if cell.ClusterIndex != cells[1][1].ClusterIndex {
+								t.Fatalf("Y=%d,X=%d が別のクラスタである", y, x)
+							}
+						}
+					}
+				}
+				goal := cells[testCase.rowLength-2][testCase.columnLength-2]
+				if goal.Content != MazeCellContentEmpty {
+					t.Fatal("右下の角が空いていない")
+				} else if countReachableEmptyCells(cells, cells[1][1]) != emptyCellCount {
+					t.Fatal("全ての空セルが結合されていない")
+				}
+			}
+		})
+	}
+
+	t.Run("完全迷路より広い空間を作る", func(t *testing.T) {
+		cells, _ := generator.Generate(21, 21, rand.New(rand.NewSource(1)))
+		hasOpenSpace := false
+		for y := 1; y < 19; y++ {
+			for x := 1; x < 19; x++ {
+				if cells[y][x].Content == MazeCellContentEmpty && cells[y][x+1].Content == MazeCellContentEmpty &&
+					cells[y+1][x].Content == MazeCellContentEmpty && cells[y+1][x+1].Content == MazeCellContentEmpty {
+					hasOpenSpace = true
+				}
+			}
+		}
+		if !hasOpenSpace {
+			t.Fatal("2*2 の空間がない")
+		}
+	})
+
+	t.Run("同じシードからは同じダンジョンを生成する", func(t *testing.T) {
+		a, _ := generator.Generate(21, 21, rand.New(rand.NewSource(123)))
+		b, _ := generator.Generate(21, 21, rand.New(rand.NewSource(123)))
+		for y, row := range a {
+			for x, cell := range row {
+				if cell.Content != b[y][x].Content {
+					t.Fatal("ダンジョンが異なる")
+				}
+			}
+		}
+	})
+}
+
+func TestIsWallBetweenRooms_NotTD(t *testing.T) {
+	t.Run("両側が空の部屋の間の壁だけを対象にする", func(t *testing.T) {
+		cells, _ := generateRawMazeMatrix(5, 5)
+		finishMaze(cells)
+		cells[2][1].Content = MazeCellContentEmpty
+		cells[3][3].Content = MazeCellContentUnbreakableWall
+		if IsWallBetweenRooms(cells, 2, 1) {
+			t.Fatal("空のセルを対象にしている")
+		} else if !IsWallBetweenRooms(cells, 1, 2) {
+			t.Fatal("部屋の間の壁を対象にしていない")
+		} else if IsWallBetweenRooms(cells, 3, 2) || IsWallBetweenRooms(cells, 2, 3) {
+			t.Fatal("片側が壁の壁を対象にしている")
+		} else if IsWallBetweenRooms(cells, 2, 2) || IsWallBetweenRooms(cells, 0, 1) {
+			t.Fatal("柱か外周を対象にしている")
+		}
+	})
+}
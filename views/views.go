@@ -40,7 +40,34 @@ type ScreenProps struct {
 	FloorNumber int
 	LankMessage string
 	LankMessageForeground termbox.Attribute
+	// Lines under the lank message, e.g. the final ranking in the race mode. ASCII only.
+	SideLines []string
 	RemainingTime float64
+	// The seed of the game's mazes. It is not shown if it is 0, i.e. before the first game.
+	Seed int64
+	// The number of walls that the player's hero can still dig on the floor.
+	DigCharges int
+	// Names of items in the inventory of the player's hero, in order. ASCII only.
+	InventoryItemNames []string
+	// Names of keys that the player's hero carries on the floor. ASCII only.
+	KeyNames []string
+}
+
+type HighScoreProps struct {
+	PlayerName string
+	Floors int
+	// e.g. "2020-01-02". ASCII only.
+	Date string
+	Seed int64
+	// e.g. "race". ASCII only.
+	Mode string
+}
+
+type HighScoresProps struct {
+	// In descending order of scores.
+	HighScores []*HighScoreProps
+	// A line under the table, e.g. an error of loading. ASCII only.
+	Message string
 }
 
 type Screen struct {
@@ -71,37 +98,22 @@ func (screen *Screen) ForEachCells(
 
 func (screen *Screen) Render(props *ScreenProps) {
 	rowLength := screen.measureRowLength()
-	columnLength := screen.measureColumnLength()
 
-	// Pad elements with blanks.
-	// Set borders.
-	for y := 0; y < rowLength; y++ {
-		for x := 0; x < columnLength; x++ {
-			isTopOrBottomEdge := y == 0 || y == rowLength-1
-			isLeftOrRightEdge := x == 0 || x == columnLength-1
-			symbol := ' '
-			switch {
-			case isTopOrBottomEdge && isLeftOrRightEdge:
-				symbol = '+'
-			case isTopOrBottomEdge && !isLeftOrRightEdge:
-				symbol = '-'
-			case !isTopOrBottomEdge && isLeftOrRightEdge:
-				symbol = '|'
-			}
-			cell := screen.matrix[y][x]
-			cell.render(&ScreenCellProps{
-				Symbol: symbol,
-				Foreground: termbox.ColorWhite,
-				Background: termbox.ColorBlack,
-			})
-		}
-	}
+	screen.renderFrame()
 
 	// Place the field.
+	// Cells out of the field area are clipped, so that they do not overlap the border and texts.
 	fieldPosition := &utils.MatrixPosition{Y: 2, X: 2}
+	fieldAreaBottom := rowLength - 2
+	fieldAreaRight := 23
 	for y, rowProps := range props.FieldCells {
 		for x, cellProps := range rowProps {
-			cell := screen.matrix[y + fieldPosition.GetY()][x + fieldPosition.GetX()]
+			screenY := y + fieldPosition.GetY()
+			screenX := x + fieldPosition.GetX()
+			if screenY > fieldAreaBottom || screenX > fieldAreaRight {
+				continue
+			}
+			cell := screen.matrix[screenY][screenX]
 			cell.render(cellProps)
 		}
 	}
@@ -121,18 +133,112 @@ func (screen *Screen) Render(props *ScreenProps) {
 		Foreground: termbox.ColorWhite,
 	}
 	texts = append(texts, floorNumberText)
+	texts = append(texts, &screenText{
+		Position: &utils.MatrixPosition{Y: 5, X: 25},
+		Text: fmt.Sprintf("Dig  : %2d", props.DigCharges),
+		Foreground: termbox.ColorWhite,
+	})
+	if props.Seed != 0 {
+		texts = append(texts, &screenText{
+			Position: &utils.MatrixPosition{Y: 2, X: 25},
+			Text: fmt.Sprintf("Seed : %d", props.Seed),
+			Foreground: termbox.ColorWhite,
+		})
+	}
 	if props.LankMessage != "" {
 		lankText := &screenText{
-			Position: &utils.MatrixPosition{Y: 5, X: 27},
+			Position: &utils.MatrixPosition{Y: 6, X: 27},
 			Text: props.LankMessage,
 			Foreground: props.LankMessageForeground,
 		}
 		texts = append(texts, lankText)
 	}
+	for index, sideLine := range props.SideLines {
+		sideText := &screenText{
+			Position: &utils.MatrixPosition{Y: 7 + index, X: 25},
+			Text: sideLine,
+			Foreground: termbox.ColorWhite,
+		}
+		// Do not overflow the bottom border.
+		if sideText.Position.GetY() >= rowLength-1 {
+			break
+		}
+		texts = append(texts, sideText)
+	}
+
+	// The inventory panel. Each item is numbered by the key to use it.
+	// It is placed right of the longest seed line and side lines, so that they do not overwrite each other.
+	inventoryPanelX := 56
+	texts = append(texts, &screenText{
+		Position: &utils.MatrixPosition{Y: 2, X: inventoryPanelX},
+		Text: "Items",
+		Foreground: termbox.ColorWhite,
+	})
+	for index, itemName := range props.InventoryItemNames {
+		texts = append(texts, &screenText{
+			Position: &utils.MatrixPosition{Y: 3 + index, X: inventoryPanelX},
+			Text: fmt.Sprintf("%d. %s", index+1, itemName),
+			Foreground: termbox.ColorCyan,
+		})
+	}
+	// Keys are listed under the items. They are not numbered, because they are used by walking into doors.
+	keysPosition := &utils.MatrixPosition{Y: 4 + len(props.InventoryItemNames), X: inventoryPanelX}
+	texts = append(texts, &screenText{
+		Position: keysPosition,
+		Text: "Keys",
+		Foreground: termbox.ColorWhite,
+	})
+	for index, keyName := range props.KeyNames {
+		texts = append(texts, &screenText{
+			Position: &utils.MatrixPosition{Y: keysPosition.GetY() + 1 + index, X: inventoryPanelX},
+			Text: keyName,
+			Foreground: termbox.ColorCyan,
+		})
+	}
+
+	screen.placeTexts(texts)
+}
 
-	// Place texts.
+// Pad elements with blanks and set borders.
+func (screen *Screen) renderFrame() {
+	rowLength := screen.measureRowLength()
+	columnLength := screen.measureColumnLength()
+	for y := 0; y < rowLength; y++ {
+		for x := 0; x < columnLength; x++ {
+			isTopOrBottomEdge := y == 0 || y == rowLength-1
+			isLeftOrRightEdge := x == 0 || x == columnLength-1
+			symbol := ' '
+			switch {
+			case isTopOrBottomEdge && isLeftOrRightEdge:
+				symbol = '+'
+			case isTopOrBottomEdge && !isLeftOrRightEdge:
+				symbol = '-'
+			case !isTopOrBottomEdge && isLeftOrRightEdge:
+				symbol = '|'
+			}
+			cell := screen.matrix[y][x]
+			cell.render(&ScreenCellProps{
+				Symbol: symbol,
+				Foreground: termbox.ColorWhite,
+				Background: termbox.ColorBlack,
+			})
+		}
+	}
+}
+
+func (screen *Screen) placeTexts(texts []*screenText) {
+	rowLength := screen.measureRowLength()
+	columnLength := screen.measureColumnLength()
 	for _, textInstance := range texts {
+		// Do not overflow the bottom border.
+		if textInstance.Position.GetY() >= rowLength-1 {
+			continue
+		}
 		for deltaX, character := range textInstance.Text {
+			// Do not overflow the right border.
+			if textInstance.Position.GetX() + deltaX >= columnLength-1 {
+				break
+			}
 			cell := screen.matrix[textInstance.Position.GetY()][textInstance.Position.GetX() + deltaX]
 			cell.render(&ScreenCellProps{
 				Symbol: character,
@@ -143,6 +249,44 @@ func (screen *Screen) Render(props *ScreenProps) {
 	}
 }
 
+// Render the high-score screen instead of the game.
+func (screen *Screen) RenderHighScores(props *HighScoresProps) {
+	screen.renderFrame()
+
+	texts := make([]*screenText, 0)
+	texts = append(texts, &screenText{
+		Position: &utils.MatrixPosition{Y: 2, X: 4},
+		Text: "High Scores",
+		Foreground: termbox.ColorCyan,
+	})
+	texts = append(texts, &screenText{
+		Position: &utils.MatrixPosition{Y: 4, X: 4},
+		Text: fmt.Sprintf("%-3s %-16s %5s  %-10s  %-8s  %s", "#", "Name", "Floor", "Date", "Mode", "Seed"),
+		Foreground: termbox.ColorWhite,
+	})
+	for index, highScore := range props.HighScores {
+		fg := termbox.ColorWhite
+		if index == 0 {
+			fg = termbox.ColorYellow
+		}
+		texts = append(texts, &screenText{
+			Position: &utils.MatrixPosition{Y: 5 + index, X: 4},
+			Text: fmt.Sprintf("%-3d %-16.16s %5d  %-10.10s  %-8.8s  %d",
+				index+1, highScore.PlayerName, highScore.Floors, highScore.Date, highScore.Mode, highScore.Seed),
+			Foreground: fg,
+		})
+	}
+	if props.Message != "" {
+		texts = append(texts, &screenText{
+			Position: &utils.MatrixPosition{Y: 6 + len(props.HighScores), X: 4},
+			Text: props.Message,
+			Foreground: termbox.ColorRed,
+		})
+	}
+
+	screen.placeTexts(texts)
+}
+
 func CreateScreen(rowLength int, columnLength int) *Screen {
 	matrix := make([][]*screenCell, rowLength)
 	for y := 0; y < rowLength; y++ {